@@ -0,0 +1,478 @@
+package aerofactory
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+const (
+	// Default failure ratio opening circuit breaker
+	DefaultBreakerFailureRatio = 0.5
+
+	// Default rolling window failure ratio is measured over
+	DefaultBreakerWindow = 10 * time.Second
+
+	// Default time open circuit breaker rejects calls for, before letting a probe call through
+	DefaultBreakerCooldown = 5 * time.Second
+
+	// Default number of calls in window before failure ratio is evaluated
+	DefaultBreakerMinRequests = 20
+)
+
+// Number of buckets rolling window is split into.
+const breakerBuckets = 10
+
+// Result codes counted as failures by [CircuitBreaker]: the cluster is unreachable or overloaded.
+// Record-level errors (e.g. missing record, generation mismatch) mean the cluster answered & never open the breaker.
+var breakerFailureResultCodes = []types.ResultCode{
+	types.NETWORK_ERROR,
+	types.TIMEOUT,
+	types.NO_RESPONSE,
+	types.INVALID_NODE_ERROR,
+	types.SERVER_NOT_AVAILABLE,
+	types.NO_AVAILABLE_CONNECTIONS_TO_NODE,
+	types.MAX_RETRIES_EXCEEDED,
+	types.DEVICE_OVERLOAD,
+}
+
+// Returns current time. Overridden in tests.
+var breakerNow = time.Now
+
+// State of [CircuitBreaker].
+type BreakerState string
+
+const (
+	// Calls pass through & their outcomes are counted
+	BreakerClosed BreakerState = "closed"
+
+	// Calls are rejected with [BreakerOpenError] until cooldown passes
+	BreakerOpen BreakerState = "open"
+
+	// Cooldown passed: next call is let through as a probe, closing the breaker on success or reopening it on failure
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// Serves as circuit breaker settings of [BreakerClient], declared with `breaker_failure_ratio`, `breaker_window`,
+// `breaker_cooldown` & `breaker_min_requests` URL query parameters.
+type Breaker struct {
+	// Share of failed calls in window opening the breaker, from 0 (exclusive) to 1.
+	// [DefaultBreakerFailureRatio], if 0
+	FailureRatio float64
+
+	// Rolling window failure ratio is measured over ([DefaultBreakerWindow], if 0)
+	Window time.Duration
+
+	// Time open breaker rejects calls for, before letting a probe call through ([DefaultBreakerCooldown], if 0)
+	Cooldown time.Duration
+
+	// Calls in window required before failure ratio is evaluated, so a few failures at low traffic
+	// do not open the breaker ([DefaultBreakerMinRequests], if 0)
+	MinRequests int
+}
+
+// Returns error, if failure ratio is not a number from 0 to 1 (e.g. NaN), or window, cooldown or min requests are negative.
+// Zero values are valid & mean defaults.
+func (breaker *Breaker) Validate() error {
+	if !(breaker.FailureRatio >= 0 && breaker.FailureRatio <= 1) {
+		return fmt.Errorf("%w: %v", ErrInvalidBreakerFailureRatio, breaker.FailureRatio)
+	}
+
+	if breaker.Window < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidBreakerWindow, breaker.Window)
+	}
+
+	if breaker.Cooldown < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidBreakerCooldown, breaker.Cooldown)
+	}
+
+	if breaker.MinRequests < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidBreakerMinRequests, breaker.MinRequests)
+	}
+
+	return nil
+}
+
+// Sets circuit breaker settings (See: [Breaker]). Client built by [AerospikeClientFactory.Build] is wrapped into [BreakerClient].
+func (cf *AerospikeClientFactory) SetBreaker(breaker *Breaker) {
+	cf.breaker = breaker
+}
+
+// Returns circuit breaker settings or nil, if they were not set.
+func (cf *AerospikeClientFactory) GetBreaker() *Breaker {
+	return cf.breaker
+}
+
+// Wraps client into [BreakerClient], if circuit breaker was set. State changes are logged.
+func (cf *AerospikeClientFactory) wrapBreaker(client Client) Client {
+	if cf.breaker == nil {
+		return client
+	}
+
+	breakerClient := NewBreakerClient(client, cf.breaker)
+	breakerClient.breaker.onStateChange = func(from BreakerState, to BreakerState) {
+		level := slog.LevelInfo
+		if to == BreakerOpen {
+			level = slog.LevelWarn
+		}
+
+		cf.log(level, "aerospike circuit breaker state changed", "from", from, "to", to)
+	}
+
+	return breakerClient
+}
+
+// Calls & failures counted within one bucket of rolling window.
+type breakerBucket struct {
+	start    time.Time
+	requests int
+	failures int
+}
+
+// Serves as circuit breaker counting failures (See: [Breaker]) of calls made with [CircuitBreaker.Do]
+// over a rolling window. Once failure ratio is reached, calls are rejected with [BreakerOpenError] without
+// reaching the cluster, so callers fail fast instead of piling up on timeouts. After cooldown, a single probe call
+// is let through: success closes the breaker, failure keeps it open for another cooldown.
+type CircuitBreaker struct {
+	failureRatio float64
+	window       time.Duration
+	cooldown     time.Duration
+	minRequests  int
+
+	mu       sync.Mutex
+	state    BreakerState
+	openedAt time.Time
+	probing  bool
+	buckets  [breakerBuckets]breakerBucket
+
+	onStateChange func(from BreakerState, to BreakerState)
+	changes       []breakerStateChange
+}
+
+// State change made under lock, for the listener to be notified of after unlock.
+type breakerStateChange struct {
+	from BreakerState
+	to   BreakerState
+}
+
+// Creates closed [CircuitBreaker] of settings, defaults are used for zero ones.
+func NewCircuitBreaker(breaker *Breaker) *CircuitBreaker {
+	circuitBreaker := &CircuitBreaker{
+		failureRatio: breaker.FailureRatio,
+		window:       breaker.Window,
+		cooldown:     breaker.Cooldown,
+		minRequests:  breaker.MinRequests,
+		state:        BreakerClosed,
+	}
+
+	if circuitBreaker.failureRatio <= 0 {
+		circuitBreaker.failureRatio = DefaultBreakerFailureRatio
+	}
+
+	if circuitBreaker.window <= 0 {
+		circuitBreaker.window = DefaultBreakerWindow
+	}
+
+	if circuitBreaker.cooldown <= 0 {
+		circuitBreaker.cooldown = DefaultBreakerCooldown
+	}
+
+	if circuitBreaker.minRequests <= 0 {
+		circuitBreaker.minRequests = DefaultBreakerMinRequests
+	}
+
+	return circuitBreaker
+}
+
+// Returns current state. Open breaker past its cooldown is reported [BreakerHalfOpen], as the next call probes the cluster.
+func (circuitBreaker *CircuitBreaker) State() BreakerState {
+	circuitBreaker.mu.Lock()
+	defer circuitBreaker.mu.Unlock()
+
+	if circuitBreaker.state == BreakerOpen && breakerNow().Sub(circuitBreaker.openedAt) >= circuitBreaker.cooldown {
+		return BreakerHalfOpen
+	}
+
+	return circuitBreaker.state
+}
+
+// Runs operation, unless breaker is open, & records its outcome.
+// Returns [BreakerOpenError] without running operation, if breaker is open or a probe call is already in flight.
+func (circuitBreaker *CircuitBreaker) Do(operation func() aerospike.Error) aerospike.Error {
+	probe, breakerErr := circuitBreaker.allow()
+	if breakerErr != nil {
+		return breakerErr
+	}
+
+	err := operation()
+	circuitBreaker.record(probe, err)
+
+	return err
+}
+
+// Admits call & reports whether it is a probe call, or returns error, if call is rejected.
+func (circuitBreaker *CircuitBreaker) allow() (bool, *BreakerOpenError) {
+	circuitBreaker.mu.Lock()
+	defer circuitBreaker.unlock()
+
+	switch circuitBreaker.state {
+	case BreakerClosed:
+		return false, nil
+	case BreakerOpen:
+		retryAfter := circuitBreaker.cooldown - breakerNow().Sub(circuitBreaker.openedAt)
+		if retryAfter > 0 {
+			return false, newBreakerOpenError(BreakerOpen, retryAfter)
+		}
+
+		circuitBreaker.transition(BreakerHalfOpen)
+	}
+
+	if circuitBreaker.probing {
+		return false, newBreakerOpenError(BreakerHalfOpen, 0)
+	}
+
+	circuitBreaker.probing = true
+	return true, nil
+}
+
+// Records outcome of admitted call.
+func (circuitBreaker *CircuitBreaker) record(probe bool, err aerospike.Error) {
+	failed := err != nil && err.Matches(breakerFailureResultCodes...)
+
+	circuitBreaker.mu.Lock()
+	defer circuitBreaker.unlock()
+
+	if probe {
+		circuitBreaker.probing = false
+
+		if failed {
+			circuitBreaker.open()
+		} else {
+			circuitBreaker.buckets = [breakerBuckets]breakerBucket{}
+			circuitBreaker.transition(BreakerClosed)
+		}
+
+		return
+	}
+
+	// Calls admitted before breaker opened do not count
+	if circuitBreaker.state != BreakerClosed {
+		return
+	}
+
+	now := breakerNow()
+	bucket := circuitBreaker.bucket(now)
+	bucket.requests++
+	if failed {
+		bucket.failures++
+	}
+
+	requests, failures := circuitBreaker.count(now)
+	if requests >= circuitBreaker.minRequests && float64(failures) >= circuitBreaker.failureRatio*float64(requests) {
+		circuitBreaker.open()
+	}
+}
+
+// Returns bucket of rolling window time falls into, reset if it belongs to an older window.
+func (circuitBreaker *CircuitBreaker) bucket(now time.Time) *breakerBucket {
+	width := circuitBreaker.window / breakerBuckets
+	if width <= 0 {
+		width = 1
+	}
+
+	// Start & index are both derived from Unix time, so a bucket is never reset within its own slot
+	slot := now.UnixNano() / int64(width)
+	start := time.Unix(0, slot*int64(width))
+	bucket := &circuitBreaker.buckets[int(slot%breakerBuckets)]
+	if !bucket.start.Equal(start) {
+		*bucket = breakerBucket{start: start}
+	}
+
+	return bucket
+}
+
+// Returns calls & failures counted within window ending at now.
+func (circuitBreaker *CircuitBreaker) count(now time.Time) (int, int) {
+	requests, failures := 0, 0
+	for _, bucket := range circuitBreaker.buckets {
+		if now.Sub(bucket.start) < circuitBreaker.window {
+			requests += bucket.requests
+			failures += bucket.failures
+		}
+	}
+
+	return requests, failures
+}
+
+// Opens breaker for cooldown.
+func (circuitBreaker *CircuitBreaker) open() {
+	circuitBreaker.openedAt = breakerNow()
+	circuitBreaker.buckets = [breakerBuckets]breakerBucket{}
+	circuitBreaker.transition(BreakerOpen)
+}
+
+// Changes state. State change listener is notified once breaker is unlocked (See: [CircuitBreaker.unlock]).
+func (circuitBreaker *CircuitBreaker) transition(state BreakerState) {
+	from := circuitBreaker.state
+	circuitBreaker.state = state
+
+	if from != state && circuitBreaker.onStateChange != nil {
+		circuitBreaker.changes = append(circuitBreaker.changes, breakerStateChange{from, state})
+	}
+}
+
+// Unlocks breaker & notifies state change listener of changes made under lock,
+// so the listener may call back into the breaker, e.g. [CircuitBreaker.State].
+func (circuitBreaker *CircuitBreaker) unlock() {
+	changes := circuitBreaker.changes
+	circuitBreaker.changes = nil
+	circuitBreaker.mu.Unlock()
+
+	for _, change := range changes {
+		circuitBreaker.onStateChange(change.from, change.to)
+	}
+}
+
+// Serves as [Client] wrapper guarding record operations with [CircuitBreaker]. While the breaker is open,
+// operations fail fast with [BreakerOpenError] without reaching the cluster.
+type BreakerClient struct {
+	Client
+
+	breaker *CircuitBreaker
+}
+
+// Wraps [Client] into [BreakerClient] with a new [CircuitBreaker] of settings.
+func NewBreakerClient(client Client, breaker *Breaker) *BreakerClient {
+	return &BreakerClient{client, NewCircuitBreaker(breaker)}
+}
+
+// Returns circuit breaker of client, e.g. to report its state in health checks & metrics.
+func (breakerClient *BreakerClient) Breaker() *CircuitBreaker {
+	return breakerClient.breaker
+}
+
+// Calls [Client.Get], unless breaker is open.
+func (breakerClient *BreakerClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (record *aerospike.Record, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		record, err = breakerClient.Client.Get(policy, key, binNames...)
+		return err
+	})
+
+	return record, err
+}
+
+// Calls [Client.GetHeader], unless breaker is open.
+func (breakerClient *BreakerClient) GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (record *aerospike.Record, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		record, err = breakerClient.Client.GetHeader(policy, key)
+		return err
+	})
+
+	return record, err
+}
+
+// Calls [Client.Exists], unless breaker is open.
+func (breakerClient *BreakerClient) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (exists bool, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		exists, err = breakerClient.Client.Exists(policy, key)
+		return err
+	})
+
+	return exists, err
+}
+
+// Calls [Client.Put], unless breaker is open.
+func (breakerClient *BreakerClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return breakerClient.breaker.Do(func() aerospike.Error {
+		return breakerClient.Client.Put(policy, key, binMap)
+	})
+}
+
+// Calls [Client.PutBins], unless breaker is open.
+func (breakerClient *BreakerClient) PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	return breakerClient.breaker.Do(func() aerospike.Error {
+		return breakerClient.Client.PutBins(policy, key, bins...)
+	})
+}
+
+// Calls [Client.Add], unless breaker is open.
+func (breakerClient *BreakerClient) Add(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return breakerClient.breaker.Do(func() aerospike.Error {
+		return breakerClient.Client.Add(policy, key, binMap)
+	})
+}
+
+// Calls [Client.Append], unless breaker is open.
+func (breakerClient *BreakerClient) Append(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return breakerClient.breaker.Do(func() aerospike.Error {
+		return breakerClient.Client.Append(policy, key, binMap)
+	})
+}
+
+// Calls [Client.Prepend], unless breaker is open.
+func (breakerClient *BreakerClient) Prepend(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return breakerClient.breaker.Do(func() aerospike.Error {
+		return breakerClient.Client.Prepend(policy, key, binMap)
+	})
+}
+
+// Calls [Client.Delete], unless breaker is open.
+func (breakerClient *BreakerClient) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (existed bool, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		existed, err = breakerClient.Client.Delete(policy, key)
+		return err
+	})
+
+	return existed, err
+}
+
+// Calls [Client.Touch], unless breaker is open.
+func (breakerClient *BreakerClient) Touch(policy *aerospike.WritePolicy, key *aerospike.Key) aerospike.Error {
+	return breakerClient.breaker.Do(func() aerospike.Error {
+		return breakerClient.Client.Touch(policy, key)
+	})
+}
+
+// Calls [Client.Operate], unless breaker is open.
+func (breakerClient *BreakerClient) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (record *aerospike.Record, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		record, err = breakerClient.Client.Operate(policy, key, operations...)
+		return err
+	})
+
+	return record, err
+}
+
+// Calls [Client.BatchGet], unless breaker is open.
+func (breakerClient *BreakerClient) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) (records []*aerospike.Record, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		records, err = breakerClient.Client.BatchGet(policy, keys, binNames...)
+		return err
+	})
+
+	return records, err
+}
+
+// Calls [Client.BatchGetHeader], unless breaker is open.
+func (breakerClient *BreakerClient) BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) (records []*aerospike.Record, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		records, err = breakerClient.Client.BatchGetHeader(policy, keys)
+		return err
+	})
+
+	return records, err
+}
+
+// Calls [Client.BatchExists], unless breaker is open.
+func (breakerClient *BreakerClient) BatchExists(policy *aerospike.BatchPolicy, keys []*aerospike.Key) (exists []bool, err aerospike.Error) {
+	err = breakerClient.breaker.Do(func() aerospike.Error {
+		exists, err = breakerClient.Client.BatchExists(policy, keys)
+		return err
+	})
+
+	return exists, err
+}
@@ -0,0 +1,258 @@
+package aerofactory
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+var _ Client = (*BreakerClient)(nil)
+
+// Serves as [Client] answering Get with err, counting calls.
+type failingClient struct {
+	Client
+
+	err   aerospike.Error
+	calls int
+}
+
+func (failing *failingClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	failing.calls++
+	if failing.err != nil {
+		return nil, failing.err
+	}
+
+	return &aerospike.Record{}, nil
+}
+
+// Freezes breaker clock for the test. Returns function moving it forward.
+func stubBreakerNow(t *testing.T) (advance func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	breakerNow = func() time.Time { return now }
+	t.Cleanup(func() { breakerNow = time.Now })
+
+	return func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	stubBreakerNow(t)
+
+	failing := &failingClient{err: &aerospike.AerospikeError{ResultCode: types.TIMEOUT}}
+	client := NewBreakerClient(failing, &Breaker{FailureRatio: 0.5, MinRequests: 4})
+
+	for i := 0; i < 4; i++ {
+		client.Get(nil, nil)
+	}
+
+	if state := client.Breaker().State(); state != BreakerOpen {
+		t.Fatalf("got: %v, want: %v", state, BreakerOpen)
+	}
+
+	_, err := client.Get(nil, nil)
+
+	var breakerErr *BreakerOpenError
+	if !errors.As(err, &breakerErr) || !errors.Is(err, ErrBreakerOpen) || !err.Matches(types.SERVER_NOT_AVAILABLE) {
+		t.Errorf("got: %v, want: *aerofactory.BreakerOpenError", err)
+	}
+
+	if breakerErr != nil && breakerErr.RetryAfter != DefaultBreakerCooldown {
+		t.Errorf("got: %v, want: %v", breakerErr.RetryAfter, DefaultBreakerCooldown)
+	}
+
+	if failing.calls != 4 {
+		t.Errorf("got: %v calls, want: rejected call skips wrapped client", failing.calls)
+	}
+}
+
+func TestCircuitBreakerMinRequestsAndRatio(t *testing.T) {
+	stubBreakerNow(t)
+
+	failing := &failingClient{err: &aerospike.AerospikeError{ResultCode: types.NETWORK_ERROR}}
+	client := NewBreakerClient(failing, &Breaker{FailureRatio: 0.5, MinRequests: 4})
+
+	for i := 0; i < 3; i++ {
+		client.Get(nil, nil)
+	}
+
+	if state := client.Breaker().State(); state != BreakerClosed {
+		t.Errorf("got: %v, want: closed below min requests", state)
+	}
+
+	// Record-level errors mean the cluster answered
+	failing = &failingClient{err: &aerospike.AerospikeError{ResultCode: types.KEY_NOT_FOUND_ERROR}}
+	client = NewBreakerClient(failing, &Breaker{FailureRatio: 0.5, MinRequests: 4})
+
+	for i := 0; i < 4; i++ {
+		client.Get(nil, nil)
+	}
+
+	failing.err = &aerospike.AerospikeError{ResultCode: types.NETWORK_ERROR}
+	for i := 0; i < 3; i++ {
+		client.Get(nil, nil)
+	}
+
+	if state := client.Breaker().State(); state != BreakerClosed {
+		t.Errorf("got: %v, want: closed below failure ratio", state)
+	}
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	advance := stubBreakerNow(t)
+
+	failing := &failingClient{err: &aerospike.AerospikeError{ResultCode: types.TIMEOUT}}
+	client := NewBreakerClient(failing, &Breaker{FailureRatio: 1, Window: 10 * time.Second, MinRequests: 2})
+
+	client.Get(nil, nil)
+	advance(11 * time.Second)
+	client.Get(nil, nil)
+
+	if state := client.Breaker().State(); state != BreakerClosed {
+		t.Errorf("got: %v, want: failures outside window not counted", state)
+	}
+
+	client.Get(nil, nil)
+	if state := client.Breaker().State(); state != BreakerOpen {
+		t.Errorf("got: %v, want: %v", state, BreakerOpen)
+	}
+}
+
+func TestCircuitBreakerBucketKeptWithinSlot(t *testing.T) {
+	// Bucket width of 700ms does not divide the offset between Go's zero time & the Unix epoch
+	circuitBreaker := NewBreakerClient(&failingClient{}, &Breaker{Window: 7 * time.Second}).Breaker()
+	width := 700 * time.Millisecond
+
+	slotStart := time.Unix(0, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()/int64(width)*int64(width))
+	circuitBreaker.bucket(slotStart).requests++
+
+	if bucket := circuitBreaker.bucket(slotStart.Add(width - 1)); bucket.requests != 1 || !bucket.start.Equal(slotStart) {
+		t.Errorf("got: %d requests since %v, want: 1 request since %v", bucket.requests, bucket.start, slotStart)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	advance := stubBreakerNow(t)
+
+	failing := &failingClient{err: &aerospike.AerospikeError{ResultCode: types.TIMEOUT}}
+	client := NewBreakerClient(failing, &Breaker{MinRequests: 1, Cooldown: time.Second})
+
+	client.Get(nil, nil)
+	advance(time.Second)
+
+	if state := client.Breaker().State(); state != BreakerHalfOpen {
+		t.Fatalf("got: %v, want: %v after cooldown", state, BreakerHalfOpen)
+	}
+
+	// Failed probe reopens breaker
+	if _, err := client.Get(nil, nil); !err.Matches(types.TIMEOUT) || failing.calls != 2 {
+		t.Errorf("got: %v, %v calls, want: probe reached wrapped client", err, failing.calls)
+	}
+
+	if state := client.Breaker().State(); state != BreakerOpen {
+		t.Errorf("got: %v, want: %v after failed probe", state, BreakerOpen)
+	}
+
+	advance(time.Second)
+	failing.err = nil
+
+	if _, err := client.Get(nil, nil); err != nil {
+		t.Errorf("got: %v, want: error = nil", err)
+	}
+
+	if state := client.Breaker().State(); state != BreakerClosed {
+		t.Errorf("got: %v, want: %v after successful probe", state, BreakerClosed)
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	advance := stubBreakerNow(t)
+
+	breaker := NewCircuitBreaker(&Breaker{MinRequests: 1, Cooldown: time.Second})
+	breaker.Do(func() aerospike.Error { return &aerospike.AerospikeError{ResultCode: types.TIMEOUT} })
+	advance(time.Second)
+
+	breaker.Do(func() aerospike.Error {
+		err := breaker.Do(func() aerospike.Error { return nil })
+
+		var breakerErr *BreakerOpenError
+		if !errors.As(err, &breakerErr) || breakerErr.State != BreakerHalfOpen {
+			t.Errorf("got: %v, want: call rejected while probe is in flight", err)
+		}
+
+		return nil
+	})
+}
+
+func TestCircuitBreakerStateChangeOutsideLock(t *testing.T) {
+	advance := stubBreakerNow(t)
+
+	circuitBreaker := NewCircuitBreaker(&Breaker{MinRequests: 1})
+
+	states := []BreakerState{}
+	circuitBreaker.onStateChange = func(from BreakerState, to BreakerState) {
+		states = append(states, circuitBreaker.State())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		circuitBreaker.Do(func() aerospike.Error { return &aerospike.AerospikeError{ResultCode: types.TIMEOUT} })
+		advance(DefaultBreakerCooldown)
+		circuitBreaker.Do(func() aerospike.Error { return nil })
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("got: state change listener deadlocked, want: listener called outside lock")
+	}
+
+	if len(states) != 3 || states[0] != BreakerOpen || states[1] != BreakerHalfOpen || states[2] != BreakerClosed {
+		t.Errorf("got: %v, want: [open half_open closed]", states)
+	}
+}
+
+func TestBuildClientWarnsBreakerIgnored(t *testing.T) {
+	var buf bytes.Buffer
+
+	factory := unreachableFactory()
+	factory.SetBreaker(&Breaker{MinRequests: 1})
+	factory.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	factory.BuildClient()
+
+	if !strings.Contains(buf.String(), "level=WARN msg=\"aerospike circuit breaker is not applied") {
+		t.Errorf("got: %v, want: warning about ignored circuit breaker", buf.String())
+	}
+}
+
+func TestBuildBreaker(t *testing.T) {
+	stub := &stubClient{}
+	stubInMemoryBuilder(t, func(cf *AerospikeClientFactory) (Client, aerospike.Error) { return stub, nil })
+
+	factory := &AerospikeClientFactory{}
+	factory.SetInMemory(true)
+	factory.SetBreaker(&Breaker{MinRequests: 1})
+	factory.SetChaos(&Chaos{ErrorRate: 1, Seed: 1})
+	factory.AllowChaos(true)
+
+	client, _ := factory.Build()
+
+	breakerClient, ok := client.(*BreakerClient)
+	if !ok {
+		t.Fatalf("got: %T, want: *aerofactory.BreakerClient", client)
+	}
+
+	// Injected timeout trips the breaker
+	client.Get(nil, nil)
+	if state := breakerClient.Breaker().State(); state != BreakerOpen {
+		t.Errorf("got: %v, want: %v", state, BreakerOpen)
+	}
+}
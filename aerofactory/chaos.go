@@ -0,0 +1,337 @@
+package aerofactory
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Fault injected by [ChaosClient], declared with comma-separated `chaos_errors` URL query parameter.
+type ChaosFault string
+
+const (
+	// Client timeout ([types.TIMEOUT])
+	//
+	// [types.TIMEOUT]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#TIMEOUT
+	ChaosFaultTimeout ChaosFault = "timeout"
+
+	// Record not found ([types.KEY_NOT_FOUND_ERROR]).
+	// [ChaosClient.Exists] & [ChaosClient.Delete] report missing record instead of error, same as Aerospike client does.
+	//
+	// [types.KEY_NOT_FOUND_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_NOT_FOUND_ERROR
+	ChaosFaultKeyNotFound ChaosFault = "key_not_found"
+
+	// Record already exists ([types.KEY_EXISTS_ERROR])
+	//
+	// [types.KEY_EXISTS_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_EXISTS_ERROR
+	ChaosFaultKeyExists ChaosFault = "key_exists"
+
+	// Record generation mismatch ([types.GENERATION_ERROR])
+	//
+	// [types.GENERATION_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#GENERATION_ERROR
+	ChaosFaultGeneration ChaosFault = "generation"
+
+	// Record is busy with too many concurrent writes ([types.KEY_BUSY])
+	//
+	// [types.KEY_BUSY]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_BUSY
+	ChaosFaultKeyBusy ChaosFault = "key_busy"
+
+	// Server device cannot keep up with writes ([types.DEVICE_OVERLOAD])
+	//
+	// [types.DEVICE_OVERLOAD]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#DEVICE_OVERLOAD
+	ChaosFaultDeviceOverload ChaosFault = "device_overload"
+
+	// Server is not accepting requests ([types.SERVER_NOT_AVAILABLE])
+	//
+	// [types.SERVER_NOT_AVAILABLE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#SERVER_NOT_AVAILABLE
+	ChaosFaultServerNotAvailable ChaosFault = "server_not_available"
+
+	// Network error ([types.NETWORK_ERROR])
+	//
+	// [types.NETWORK_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#NETWORK_ERROR
+	ChaosFaultNetwork ChaosFault = "network"
+)
+
+// Result codes of faults.
+var chaosFaultResultCodes = map[ChaosFault]types.ResultCode{
+	ChaosFaultTimeout:            types.TIMEOUT,
+	ChaosFaultKeyNotFound:        types.KEY_NOT_FOUND_ERROR,
+	ChaosFaultKeyExists:          types.KEY_EXISTS_ERROR,
+	ChaosFaultGeneration:         types.GENERATION_ERROR,
+	ChaosFaultKeyBusy:            types.KEY_BUSY,
+	ChaosFaultDeviceOverload:     types.DEVICE_OVERLOAD,
+	ChaosFaultServerNotAvailable: types.SERVER_NOT_AVAILABLE,
+	ChaosFaultNetwork:            types.NETWORK_ERROR,
+}
+
+// Returns Aerospike result code of fault. Reports false, if fault is unknown.
+func (fault ChaosFault) ResultCode() (types.ResultCode, bool) {
+	code, ok := chaosFaultResultCodes[fault]
+	return code, ok
+}
+
+// Serves as fault injection settings of [ChaosClient], declared with `chaos_latency`, `chaos_error_rate`,
+// `chaos_errors` & `chaos_seed` URL query parameters.
+type Chaos struct {
+	// Latency added to every call
+	Latency time.Duration
+
+	// Share of calls failed with injected fault, from 0 to 1
+	ErrorRate float64
+
+	// Faults calls are failed with, picked at random. [ChaosFaultTimeout] is injected, if none are set
+	Errors []ChaosFault
+
+	// Seed of random source deciding which calls fail with which fault, so a failing run can be replayed.
+	// Current time is used, if seed is 0
+	Seed int64
+}
+
+// Returns error, if latency is negative, error rate is not a number from 0 to 1 (e.g. NaN) or any fault is unknown.
+// Zero values are valid & mean defaults.
+func (chaos *Chaos) Validate() error {
+	if chaos.Latency < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidChaosLatency, chaos.Latency)
+	}
+
+	if !(chaos.ErrorRate >= 0 && chaos.ErrorRate <= 1) {
+		return fmt.Errorf("%w: %v", ErrInvalidChaosErrorRate, chaos.ErrorRate)
+	}
+
+	for _, fault := range chaos.Errors {
+		if _, ok := fault.ResultCode(); !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidChaosErrors, fault)
+		}
+	}
+
+	return nil
+}
+
+// Sets fault injection settings (See: [Chaos]). They are applied by [AerospikeClientFactory.Build]
+// only if chaos was explicitly allowed (See: [AerospikeClientFactory.AllowChaos]).
+func (cf *AerospikeClientFactory) SetChaos(chaos *Chaos) {
+	cf.chaos = chaos
+}
+
+// Returns fault injection settings or nil, if they were not set.
+func (cf *AerospikeClientFactory) GetChaos() *Chaos {
+	return cf.chaos
+}
+
+// Allows [AerospikeClientFactory.Build] to wrap client into [ChaosClient].
+// Chaos is never enabled from connection string alone, so fault injection cannot slip into production from config.
+func (cf *AerospikeClientFactory) AllowChaos(allow bool) {
+	cf.chaosAllowed = allow
+}
+
+// Reports whether chaos was allowed.
+func (cf *AerospikeClientFactory) ChaosAllowed() bool {
+	return cf.chaosAllowed
+}
+
+// Wraps client into [ChaosClient], if chaos was set & allowed. Chaos set, but not allowed is logged & ignored.
+func (cf *AerospikeClientFactory) wrapChaos(client Client) Client {
+	if cf.chaos == nil {
+		return client
+	}
+
+	if !cf.chaosAllowed {
+		cf.log(slog.LevelWarn, "aerospike chaos is not allowed, ignored")
+		return client
+	}
+
+	cf.log(slog.LevelWarn, "aerospike chaos enabled", "latency", cf.chaos.Latency, "error_rate", cf.chaos.ErrorRate, "errors", cf.chaos.Errors)
+	return NewChaosClient(client, cf.chaos)
+}
+
+// Serves as [Client] wrapper injecting latency & faults (See: [Chaos]) into record operations, e.g. to rehearse
+// how a service behaves when Aerospike DB degrades. Injected faults are [ChaosError] & skip the wrapped client.
+// Faults are decided in call order by a random source of [Chaos.Seed], so sequential calls fail the same way on every run.
+type ChaosClient struct {
+	Client
+
+	latency   time.Duration
+	errorRate float64
+	faults    []ChaosFault
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// Wraps [Client] into [ChaosClient].
+func NewChaosClient(client Client, chaos *Chaos) *ChaosClient {
+	faults := chaos.Errors
+	if len(faults) == 0 {
+		faults = []ChaosFault{ChaosFaultTimeout}
+	}
+
+	seed := chaos.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &ChaosClient{
+		Client:    client,
+		latency:   chaos.Latency,
+		errorRate: chaos.ErrorRate,
+		faults:    append([]ChaosFault(nil), faults...),
+		random:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Waits for injected latency & returns injected fault or nil, if call is not failed.
+func (chaosClient *ChaosClient) inject() *ChaosError {
+	chaosClient.mu.Lock()
+	var chaosErr *ChaosError
+	if chaosClient.errorRate > 0 && chaosClient.random.Float64() < chaosClient.errorRate {
+		chaosErr = newChaosError(chaosClient.faults[chaosClient.random.Intn(len(chaosClient.faults))])
+	}
+	chaosClient.mu.Unlock()
+
+	if chaosClient.latency > 0 {
+		time.Sleep(chaosClient.latency)
+	}
+
+	return chaosErr
+}
+
+// Calls [Client.Get] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		return nil, err
+	}
+
+	return chaosClient.Client.Get(policy, key, binNames...)
+}
+
+// Calls [Client.GetHeader] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		return nil, err
+	}
+
+	return chaosClient.Client.GetHeader(policy, key)
+}
+
+// Calls [Client.Exists] after injected latency, unless fault is injected.
+// Injected [ChaosFaultKeyNotFound] reports missing record.
+func (chaosClient *ChaosClient) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		if err.Fault == ChaosFaultKeyNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return chaosClient.Client.Exists(policy, key)
+}
+
+// Calls [Client.Put] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := chaosClient.inject(); err != nil {
+		return err
+	}
+
+	return chaosClient.Client.Put(policy, key, binMap)
+}
+
+// Calls [Client.PutBins] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	if err := chaosClient.inject(); err != nil {
+		return err
+	}
+
+	return chaosClient.Client.PutBins(policy, key, bins...)
+}
+
+// Calls [Client.Add] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Add(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := chaosClient.inject(); err != nil {
+		return err
+	}
+
+	return chaosClient.Client.Add(policy, key, binMap)
+}
+
+// Calls [Client.Append] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Append(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := chaosClient.inject(); err != nil {
+		return err
+	}
+
+	return chaosClient.Client.Append(policy, key, binMap)
+}
+
+// Calls [Client.Prepend] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Prepend(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := chaosClient.inject(); err != nil {
+		return err
+	}
+
+	return chaosClient.Client.Prepend(policy, key, binMap)
+}
+
+// Calls [Client.Delete] after injected latency, unless fault is injected.
+// Injected [ChaosFaultKeyNotFound] reports missing record.
+func (chaosClient *ChaosClient) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		if err.Fault == ChaosFaultKeyNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return chaosClient.Client.Delete(policy, key)
+}
+
+// Calls [Client.Touch] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Touch(policy *aerospike.WritePolicy, key *aerospike.Key) aerospike.Error {
+	if err := chaosClient.inject(); err != nil {
+		return err
+	}
+
+	return chaosClient.Client.Touch(policy, key)
+}
+
+// Calls [Client.Operate] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		return nil, err
+	}
+
+	return chaosClient.Client.Operate(policy, key, operations...)
+}
+
+// Calls [Client.BatchGet] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) ([]*aerospike.Record, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		return nil, err
+	}
+
+	return chaosClient.Client.BatchGet(policy, keys, binNames...)
+}
+
+// Calls [Client.BatchGetHeader] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]*aerospike.Record, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		return nil, err
+	}
+
+	return chaosClient.Client.BatchGetHeader(policy, keys)
+}
+
+// Calls [Client.BatchExists] after injected latency, unless fault is injected.
+func (chaosClient *ChaosClient) BatchExists(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]bool, aerospike.Error) {
+	if err := chaosClient.inject(); err != nil {
+		return nil, err
+	}
+
+	return chaosClient.Client.BatchExists(policy, keys)
+}
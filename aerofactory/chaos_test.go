@@ -0,0 +1,166 @@
+package aerofactory
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+var _ Client = (*ChaosClient)(nil)
+
+// Serves as [Client] answering every call successfully, counting calls.
+type stubClient struct {
+	Client
+
+	calls int
+}
+
+func (stub *stubClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	stub.calls++
+	return &aerospike.Record{}, nil
+}
+
+func (stub *stubClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	stub.calls++
+	return nil
+}
+
+func (stub *stubClient) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	stub.calls++
+	return true, nil
+}
+
+// Returns which of count Get calls failed & with which fault.
+func chaosFaults(client *ChaosClient, count int) []ChaosFault {
+	faults := make([]ChaosFault, count)
+	for i := range faults {
+		_, err := client.Get(nil, nil)
+
+		var chaosErr *ChaosError
+		if errors.As(err, &chaosErr) {
+			faults[i] = chaosErr.Fault
+		}
+	}
+
+	return faults
+}
+
+func TestChaosClientDeterministicUnderSeed(t *testing.T) {
+	chaos := &Chaos{ErrorRate: 0.5, Errors: []ChaosFault{ChaosFaultTimeout, ChaosFaultKeyNotFound}, Seed: 42}
+
+	first := chaosFaults(NewChaosClient(&stubClient{}, chaos), 50)
+	second := chaosFaults(NewChaosClient(&stubClient{}, chaos), 50)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("got: %v & %v, want: same faults under the same seed", first, second)
+	}
+
+	injected := map[ChaosFault]int{}
+	for _, fault := range first {
+		injected[fault]++
+	}
+
+	if injected[ChaosFaultTimeout] == 0 || injected[ChaosFaultKeyNotFound] == 0 || injected[""] == 0 {
+		t.Errorf("got: %v, want: calls passed & failed with both faults", injected)
+	}
+}
+
+func TestChaosClientErrorRate(t *testing.T) {
+	stub := &stubClient{}
+	client := NewChaosClient(stub, &Chaos{Seed: 1})
+
+	if faults := chaosFaults(client, 20); !reflect.DeepEqual(faults, make([]ChaosFault, 20)) || stub.calls != 20 {
+		t.Errorf("got: %v, %v calls, want: no faults at zero error rate", faults, stub.calls)
+	}
+
+	stub = &stubClient{}
+	client = NewChaosClient(stub, &Chaos{ErrorRate: 1, Seed: 1})
+
+	err := client.Put(nil, nil, nil)
+	if !errors.Is(err, ErrChaos) || !errors.Is(err, aerospike.ErrTimeout) || !err.Matches(types.TIMEOUT) {
+		t.Errorf("got: %v, want: injected timeout", err)
+	}
+
+	if stub.calls != 0 {
+		t.Errorf("got: %v calls, want: failed call skips wrapped client", stub.calls)
+	}
+}
+
+func TestChaosClientKeyNotFoundExists(t *testing.T) {
+	client := NewChaosClient(&stubClient{}, &Chaos{ErrorRate: 1, Errors: []ChaosFault{ChaosFaultKeyNotFound}, Seed: 1})
+
+	if exists, err := client.Exists(nil, nil); exists || err != nil {
+		t.Errorf("got: %v, %v, want: missing record reported without error", exists, err)
+	}
+
+	if _, err := client.Get(nil, nil); !errors.Is(err, aerospike.ErrKeyNotFound) {
+		t.Errorf("got: %v, want: error is aerospike.ErrKeyNotFound", err)
+	}
+}
+
+func TestChaosClientLatency(t *testing.T) {
+	client := NewChaosClient(&stubClient{}, &Chaos{Latency: 20 * time.Millisecond, Seed: 1})
+
+	started := time.Now()
+	client.Get(nil, nil)
+
+	if elapsed := time.Since(started); elapsed < 20*time.Millisecond {
+		t.Errorf("got: %v, want: at least 20ms", elapsed)
+	}
+}
+
+func TestBuildChaosAllowed(t *testing.T) {
+	stub := &stubClient{}
+	stubInMemoryBuilder(t, func(cf *AerospikeClientFactory) (Client, aerospike.Error) { return stub, nil })
+
+	factory := &AerospikeClientFactory{}
+	factory.SetInMemory(true)
+	factory.SetChaos(&Chaos{ErrorRate: 1, Seed: 1})
+
+	client, err := factory.Build()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if client != stub {
+		t.Errorf("got: %T, want: chaos ignored unless allowed", client)
+	}
+
+	factory.AllowChaos(true)
+
+	client, _ = factory.Build()
+	if _, ok := client.(*ChaosClient); !ok {
+		t.Errorf("got: %T, want: *aerofactory.ChaosClient", client)
+	}
+}
+
+func TestBuildClientWarnsChaosIgnored(t *testing.T) {
+	var buf bytes.Buffer
+
+	factory := unreachableFactory()
+	factory.SetChaos(&Chaos{ErrorRate: 1})
+	factory.AllowChaos(true)
+	factory.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	factory.BuildClient()
+
+	if !strings.Contains(buf.String(), "level=WARN msg=\"aerospike chaos is not injected") {
+		t.Errorf("got: %v, want: warning about ignored chaos", buf.String())
+	}
+}
+
+func TestBuildFailureReturnsNilClient(t *testing.T) {
+	factory := unreachableFactory()
+
+	client, err := factory.Build()
+	if err == nil || client != nil {
+		t.Errorf("got: %#v, %v, want: nil client & error", client, err)
+	}
+}
@@ -0,0 +1,117 @@
+package aerofactory
+
+import (
+	"sync"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Serves as interface of commonly used [aerospike.Client] methods, so service code can be unit tested
+// with a test double (See: aerospikeurltest package). Implemented by [aerospike.Client], [ReadOnlyClient], [TracedClient], [ChaosClient], [BreakerClient] & [RateLimitClient].
+//
+// Queries & scans are not included, since [aerospike.Recordset] cannot be created outside Aerospike client.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+// [aerospike.Recordset]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Recordset
+type Client interface {
+	Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error)
+	GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (*aerospike.Record, aerospike.Error)
+	Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (bool, aerospike.Error)
+
+	Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error
+	PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error
+	Add(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error
+	Append(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error
+	Prepend(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error
+	Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error)
+	Touch(policy *aerospike.WritePolicy, key *aerospike.Key) aerospike.Error
+	Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error)
+
+	BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) ([]*aerospike.Record, aerospike.Error)
+	BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]*aerospike.Record, aerospike.Error)
+	BatchExists(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]bool, aerospike.Error)
+
+	IsConnected() bool
+	Close()
+}
+
+// Builds in-memory [Client] for factory of `mem://` or `aerospike+mem://` URL.
+// Set with [RegisterInMemoryBuilder], so test doubles are never linked into production binaries.
+type InMemoryBuilder func(cf *AerospikeClientFactory) (Client, aerospike.Error)
+
+var (
+	inMemoryBuilderMutex sync.RWMutex
+	inMemoryBuilder      InMemoryBuilder
+)
+
+// Registers builder of in-memory clients. Called by aerospikeurltest package on import.
+func RegisterInMemoryBuilder(builder InMemoryBuilder) {
+	inMemoryBuilderMutex.Lock()
+	defer inMemoryBuilderMutex.Unlock()
+
+	inMemoryBuilder = builder
+}
+
+// Sets whether factory builds in-memory client (See: [AerospikeClientFactory.Build]).
+func (cf *AerospikeClientFactory) SetInMemory(inMemory bool) {
+	cf.inMemory = inMemory
+}
+
+// Reports whether factory builds in-memory client.
+func (cf *AerospikeClientFactory) GetInMemory() bool {
+	return cf.inMemory
+}
+
+// Builds client as [Client]:
+//   - in-memory client of registered [InMemoryBuilder], if factory is in-memory (See: [AerospikeClientFactory.SetInMemory]),
+//   - [ReadOnlyClient] in [ModeReadOnly] (See: [AerospikeClientFactory.BuildReadOnlyClient]),
+//   - [aerospike.Client] otherwise (See: [AerospikeClientFactory.BuildClient]).
+//
+// Built client is wrapped into [ChaosClient], if chaos was set & allowed (See: [AerospikeClientFactory.AllowChaos]),
+// & then into [BreakerClient], if circuit breaker was set (See: [AerospikeClientFactory.SetBreaker]),
+// so injected faults trip the breaker the same way real ones do, & finally into [RateLimitClient],
+// if rate limits were set (See: [AerospikeClientFactory.SetRateLimits]).
+// Returns error, if factory is in-memory & no builder was registered.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) Build() (Client, aerospike.Error) {
+	client, err := cf.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return cf.wrapRateLimit(cf.wrapBreaker(cf.wrapChaos(client))), nil
+}
+
+// Builds unwrapped client as [Client] (See: [AerospikeClientFactory.Build]).
+func (cf *AerospikeClientFactory) build() (Client, aerospike.Error) {
+	if cf.inMemory {
+		inMemoryBuilderMutex.RLock()
+		builder := inMemoryBuilder
+		inMemoryBuilderMutex.RUnlock()
+
+		if builder == nil {
+			return nil, newFactoryError(types.PARAMETER_ERROR, ErrInMemoryNotRegistered)
+		}
+
+		return builder(cf)
+	}
+
+	// Typed nil clients are not returned, so failed builds return nil Client
+	if cf.GetMode() == ModeReadOnly {
+		client, err := cf.BuildReadOnlyClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return client, nil
+	}
+
+	client, err := cf.buildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
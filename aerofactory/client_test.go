@@ -0,0 +1,80 @@
+package aerofactory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+var (
+	_ Client = (*aerospike.Client)(nil)
+	_ Client = (*ReadOnlyClient)(nil)
+	_ Client = (*TracedClient)(nil)
+)
+
+// Replaces registered in-memory builder for the duration of the test.
+func stubInMemoryBuilder(t *testing.T, builder InMemoryBuilder) {
+	inMemoryBuilderMutex.RLock()
+	previous := inMemoryBuilder
+	inMemoryBuilderMutex.RUnlock()
+
+	RegisterInMemoryBuilder(builder)
+	t.Cleanup(func() { RegisterInMemoryBuilder(previous) })
+}
+
+func TestBuildInMemory(t *testing.T) {
+	want := &ReadOnlyClient{}
+
+	var gotFactory *AerospikeClientFactory
+	stubInMemoryBuilder(t, func(cf *AerospikeClientFactory) (Client, aerospike.Error) {
+		gotFactory = cf
+		return want, nil
+	})
+
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("orders", 0, "aero-namespace-001")
+	factory.SetInMemory(true)
+
+	client, err := factory.Build()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if client != want || gotFactory != factory {
+		t.Errorf("got: %v, %v, want: client of registered builder", client, gotFactory)
+	}
+}
+
+func TestBuildInMemoryNotRegistered(t *testing.T) {
+	stubInMemoryBuilder(t, nil)
+
+	factory := &AerospikeClientFactory{}
+	factory.SetInMemory(true)
+
+	if _, err := factory.Build(); !errors.Is(err, ErrInMemoryNotRegistered) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrInMemoryNotRegistered", err)
+	}
+}
+
+func TestBuildClientInMemory(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetInMemory(true)
+
+	if _, err := factory.BuildClient(); !errors.Is(err, ErrInMemory) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrInMemory", err)
+	}
+}
+
+func TestBuildReadOnlyMode(t *testing.T) {
+	factory := unreachableFactory()
+	factory.SetMode(ModeReadOnly)
+
+	// Read-only mode builds ReadOnlyClient
+	_, err := factory.Build()
+
+	var readOnlyErr *ReadOnlyError
+	if err == nil || errors.As(err, &readOnlyErr) {
+		t.Errorf("got: %v, want: connection error", err)
+	}
+}
@@ -0,0 +1,322 @@
+// Factory package.
+// Aerospike DB hostname, port & client policy are assembled into DB client factory here.
+package aerofactory
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+var (
+	// Factory JSON document contains no Aerospike DB hosts
+	ErrNoHosts = errors.New("aerospike hosts cannot be empty")
+
+	// Factory JSON document contains unknown auth mode
+	ErrInvalidAuthMode = errors.New("invalid auth mode, want: auth_mode_internal, auth_mode_external or auth_mode_pki")
+
+	// Lazy client was used after it was closed
+	ErrLazyClientClosed = errors.New("aerospike lazy client is closed")
+
+	// Namespace-bound client was built from factory without namespace
+	ErrEmptyNamespace = errors.New("aerospike namespace cannot be empty")
+
+	// Key or statement targets a namespace other than the one client is bound to
+	ErrNamespaceMismatch = errors.New("aerospike namespace does not match client namespace")
+
+	// Nil statement was passed to [NamespaceClient.Query]
+	ErrNilStatement = errors.New("aerospike statement cannot be nil")
+
+	// Write operation was called on read-only client or [aerospike.Client] build was refused in read-only mode
+	ErrReadOnly = errors.New("aerospike client is read-only")
+
+	// Connected client failed preflight checks
+	ErrPreflight = errors.New("aerospike preflight checks failed")
+
+	// Client build was vetoed by [Hooks.BeforeBuild]
+	ErrBuildVetoed = errors.New("aerospike client build vetoed")
+
+	// In-memory factory was built without registered in-memory builder
+	ErrInMemoryNotRegistered = errors.New("aerospike in-memory client is not registered, want: import aerospikeurltest package")
+
+	// [aerospike.Client] was built from in-memory factory
+	ErrInMemory = errors.New("aerospike in-memory factory builds aerofactory.Client only, want: Build")
+
+	// [Chaos] latency is negative
+	ErrInvalidChaosLatency = errors.New("invalid chaos_latency, want: non-negative duration, e.g. 20ms")
+
+	// [Chaos] error rate is not a number from 0 to 1
+	ErrInvalidChaosErrorRate = errors.New("invalid chaos_error_rate, want: number from 0 to 1, e.g. 0.01")
+
+	// [Chaos] faults contain unknown fault
+	ErrInvalidChaosErrors = errors.New("invalid chaos_errors, want: comma-separated timeout, key_not_found, key_exists, generation, key_busy, device_overload, server_not_available or network")
+
+	// Fault was injected by [ChaosClient]
+	ErrChaos = errors.New("aerospike fault injected")
+
+	// [Breaker] failure ratio is not a number above 0 up to 1
+	ErrInvalidBreakerFailureRatio = errors.New("invalid breaker_failure_ratio, want: number above 0 up to 1, e.g. 0.5")
+
+	// [Breaker] window is not a positive duration
+	ErrInvalidBreakerWindow = errors.New("invalid breaker_window, want: positive duration, e.g. 10s")
+
+	// [Breaker] cooldown is not a positive duration
+	ErrInvalidBreakerCooldown = errors.New("invalid breaker_cooldown, want: positive duration, e.g. 5s")
+
+	// [Breaker] min requests is not a positive integer
+	ErrInvalidBreakerMinRequests = errors.New("invalid breaker_min_requests, want: positive integer")
+
+	// [RateLimit] rate is not a positive finite number
+	ErrInvalidRateLimit = errors.New("invalid rate_limit, want: positive rate per second, minute or hour, e.g. 5000/s")
+
+	// [RateLimit] burst is not a positive integer or is set without rate limit
+	ErrInvalidRateBurst = errors.New("invalid rate_burst, want: positive integer with rate_limit")
+
+	// [RateLimits] mode is not one of supported modes
+	ErrInvalidRateLimitMode = errors.New("invalid rate_limit_mode, want: wait or fail_fast")
+
+	// Call was rejected by open [CircuitBreaker]
+	ErrBreakerOpen = errors.New("aerospike circuit breaker is open")
+
+	// Call was rejected by [RateLimitClient]
+	ErrRateLimited = errors.New("aerospike client rate limit exceeded")
+)
+
+// Serves as [aerospike.Error] for errors raised by the factory itself (not by Aerospike client),
+// so factory methods keep returning [aerospike.Error] like the client does.
+// Underlying error is available with [errors.Is] & [errors.As].
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+type FactoryError struct {
+	*aerospike.AerospikeError
+
+	err error
+}
+
+// Wraps error into [FactoryError] with Aerospike result code.
+func newFactoryError(code types.ResultCode, err error) *FactoryError {
+	return &FactoryError{&aerospike.AerospikeError{ResultCode: code}, err}
+}
+
+// Returns underlying error message.
+func (factoryErr *FactoryError) Error() string {
+	return factoryErr.err.Error()
+}
+
+// Returns underlying error.
+func (factoryErr *FactoryError) Unwrap() error {
+	return factoryErr.err
+}
+
+// Serves as [aerospike.Error] for write operations refused by [ReadOnlyClient] before reaching the server.
+// Matches [ErrReadOnly] with [errors.Is] & [types.FAIL_FORBIDDEN] with [aerospike.Error.Matches].
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [aerospike.Error.Matches]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [types.FAIL_FORBIDDEN]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#FAIL_FORBIDDEN
+type ReadOnlyError struct {
+	*aerospike.AerospikeError
+
+	// Refused operation, e.g. `Put`
+	Operation string
+}
+
+// Creates [ReadOnlyError] for refused operation.
+func newReadOnlyError(operation string) *ReadOnlyError {
+	return &ReadOnlyError{&aerospike.AerospikeError{ResultCode: types.FAIL_FORBIDDEN}, operation}
+}
+
+// Returns error message naming refused operation.
+func (readOnlyErr *ReadOnlyError) Error() string {
+	return ErrReadOnly.Error() + ": " + readOnlyErr.Operation + " refused"
+}
+
+// Returns [ErrReadOnly].
+func (readOnlyErr *ReadOnlyError) Unwrap() error {
+	return ErrReadOnly
+}
+
+// Serves as [aerospike.Error] describing everything preflight checks (See: [Preflight]) found missing.
+// Matches [ErrPreflight] with [errors.Is]. Result code is [types.INVALID_NAMESPACE], if namespace is missing,
+// otherwise [types.ROLE_VIOLATION].
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [types.INVALID_NAMESPACE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#INVALID_NAMESPACE
+// [types.ROLE_VIOLATION]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#ROLE_VIOLATION
+type PreflightError struct {
+	*aerospike.AerospikeError
+
+	// Verified namespace, empty if namespace was not verified
+	Namespace string
+
+	// Names of cluster nodes verified namespace is missing on
+	NodesMissingNamespace []string
+
+	// Client user roles were verified for, empty if client has no user
+	User string
+
+	// Required roles client user is not granted
+	MissingRoles []string
+}
+
+// Sets result code matching what is missing.
+func (preflightErr *PreflightError) withResultCode() *PreflightError {
+	code := types.ROLE_VIOLATION
+	if len(preflightErr.NodesMissingNamespace) > 0 {
+		code = types.INVALID_NAMESPACE
+	}
+
+	preflightErr.AerospikeError = &aerospike.AerospikeError{ResultCode: code}
+	return preflightErr
+}
+
+// Returns error message listing everything missing.
+func (preflightErr *PreflightError) Error() string {
+	problems := []string{}
+
+	if len(preflightErr.NodesMissingNamespace) > 0 {
+		problems = append(problems, fmt.Sprintf("namespace %s is missing on nodes %s",
+			preflightErr.Namespace, strings.Join(preflightErr.NodesMissingNamespace, ", ")))
+	}
+
+	if len(preflightErr.MissingRoles) > 0 {
+		user := preflightErr.User
+		if user == "" {
+			user = "(no user)"
+		}
+
+		problems = append(problems, fmt.Sprintf("user %s is missing roles %s",
+			user, strings.Join(preflightErr.MissingRoles, ", ")))
+	}
+
+	return ErrPreflight.Error() + ": " + strings.Join(problems, "; ")
+}
+
+// Returns [ErrPreflight].
+func (preflightErr *PreflightError) Unwrap() error {
+	return ErrPreflight
+}
+
+// Serves as [aerospike.Error] for faults injected by [ChaosClient].
+// Matches [ErrChaos] with [errors.Is], as well as result code of the fault with [aerospike.Error.Matches]
+// & [errors.Is] (e.g. [aerospike.ErrTimeout]), so injected faults are handled the same way as real ones.
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [aerospike.Error.Matches]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [aerospike.ErrTimeout]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ErrTimeout
+type ChaosError struct {
+	*aerospike.AerospikeError
+
+	// Injected fault, e.g. `timeout`
+	Fault ChaosFault
+}
+
+// Creates [ChaosError] with result code of fault.
+func newChaosError(fault ChaosFault) *ChaosError {
+	code, _ := fault.ResultCode()
+	return &ChaosError{&aerospike.AerospikeError{ResultCode: code}, fault}
+}
+
+// Returns error message naming injected fault.
+func (chaosErr *ChaosError) Error() string {
+	return ErrChaos.Error() + ": " + string(chaosErr.Fault)
+}
+
+// Returns [ErrChaos].
+func (chaosErr *ChaosError) Unwrap() error {
+	return ErrChaos
+}
+
+// Serves as [aerospike.Error] for calls rejected by open [CircuitBreaker].
+// Matches [ErrBreakerOpen] with [errors.Is] & [types.SERVER_NOT_AVAILABLE] with [aerospike.Error.Matches],
+// so rejected calls are handled the same way as calls to an unavailable cluster.
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [aerospike.Error.Matches]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [types.SERVER_NOT_AVAILABLE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#SERVER_NOT_AVAILABLE
+type BreakerOpenError struct {
+	*aerospike.AerospikeError
+
+	// State breaker rejected call in: [BreakerOpen], or [BreakerHalfOpen] while a probe call is in flight
+	State BreakerState
+
+	// Time left until breaker lets a probe call through, 0 in [BreakerHalfOpen]
+	RetryAfter time.Duration
+}
+
+// Creates [BreakerOpenError] with [types.SERVER_NOT_AVAILABLE] result code.
+//
+// [types.SERVER_NOT_AVAILABLE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#SERVER_NOT_AVAILABLE
+func newBreakerOpenError(state BreakerState, retryAfter time.Duration) *BreakerOpenError {
+	return &BreakerOpenError{&aerospike.AerospikeError{ResultCode: types.SERVER_NOT_AVAILABLE}, state, retryAfter}
+}
+
+// Returns error message with time left until probe call.
+func (breakerErr *BreakerOpenError) Error() string {
+	if breakerErr.State == BreakerHalfOpen {
+		return ErrBreakerOpen.Error() + ": probe call in flight"
+	}
+
+	return ErrBreakerOpen.Error() + ": retry after " + breakerErr.RetryAfter.String()
+}
+
+// Returns [ErrBreakerOpen].
+func (breakerErr *BreakerOpenError) Unwrap() error {
+	return ErrBreakerOpen
+}
+
+// Serves as [aerospike.Error] for calls rejected by [RateLimitClient].
+// Matches [ErrRateLimited] with [errors.Is] & [types.QUOTA_EXCEEDED] with [aerospike.Error.Matches].
+// Calls rejected as context was done also match context error (e.g. [context.Canceled]) with [errors.Is].
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [aerospike.Error.Matches]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [types.QUOTA_EXCEEDED]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#QUOTA_EXCEEDED
+type RateLimitError struct {
+	*aerospike.AerospikeError
+
+	// Class of rejected operation
+	Class OperationClass
+
+	// Time until a token would have been available, 0 if context was done
+	RetryAfter time.Duration
+
+	// Context error, if call stopped waiting for a token as context was done
+	Cause error
+}
+
+// Creates [RateLimitError] with [types.QUOTA_EXCEEDED] result code.
+//
+// [types.QUOTA_EXCEEDED]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#QUOTA_EXCEEDED
+func newRateLimitError(class OperationClass, retryAfter time.Duration, cause error) *RateLimitError {
+	return &RateLimitError{&aerospike.AerospikeError{ResultCode: types.QUOTA_EXCEEDED}, class, retryAfter, cause}
+}
+
+// Returns error message with operation class & time until a token is available or context error.
+func (rateLimitErr *RateLimitError) Error() string {
+	if rateLimitErr.Cause != nil {
+		return ErrRateLimited.Error() + ": " + string(rateLimitErr.Class) + ": " + rateLimitErr.Cause.Error()
+	}
+
+	return ErrRateLimited.Error() + ": " + string(rateLimitErr.Class) + ": retry after " + rateLimitErr.RetryAfter.String()
+}
+
+// Reports whether target is context error call stopped waiting on, or matches result code (See: [aerospike.AerospikeError.Is]).
+//
+// [aerospike.AerospikeError.Is]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#AerospikeError.Is
+func (rateLimitErr *RateLimitError) Is(target error) bool {
+	if rateLimitErr.Cause != nil && errors.Is(rateLimitErr.Cause, target) {
+		return true
+	}
+
+	return rateLimitErr.AerospikeError.Is(target)
+}
+
+// Returns [ErrRateLimited].
+func (rateLimitErr *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
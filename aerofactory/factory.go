@@ -3,7 +3,11 @@
 package aerofactory
 
 import (
+	"log/slog"
+	"time"
+
 	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
 )
 
 // Serves for assembling hostname, port & [aerospike.ClientPolicy] for building [aerospike.Client].
@@ -14,8 +18,29 @@ type AerospikeClientFactory struct {
 	hostname  string
 	port      int
 	namespace string
+	set       string
+
+	hosts []*aerospike.Host
+
+	policy   *aerospike.ClientPolicy
+	tlsFiles *TLSFiles
+
+	mode      Mode
+	preflight *Preflight
+	warmUp    *WarmUp
+
+	logger   *slog.Logger
+	logLevel LogLevel
+
+	hooks []Hooks
 
-	policy *aerospike.ClientPolicy
+	inMemory bool
+
+	chaos        *Chaos
+	chaosAllowed bool
+
+	breaker    *Breaker
+	rateLimits *RateLimits
 }
 
 func (cf *AerospikeClientFactory) SetAddress(hostname string, port int, namespace string) {
@@ -54,6 +79,36 @@ func (cf *AerospikeClientFactory) GetNamespace() string {
 	return cf.namespace
 }
 
+// Sets default Aerospike DB set.
+func (cf *AerospikeClientFactory) SetSet(set string) {
+	cf.set = set
+}
+
+// Returns default Aerospike DB set or empty string, if not set.
+func (cf *AerospikeClientFactory) GetSet() string {
+	return cf.set
+}
+
+// Sets Aerospike DB seed hosts [aerospike.Host].
+// When hosts are set, they are used for building client instead of hostname & port.
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func (cf *AerospikeClientFactory) SetHosts(hosts ...*aerospike.Host) {
+	cf.hosts = hosts
+}
+
+// Returns Aerospike DB seed hosts [aerospike.Host].
+// If no seed hosts were set, a single host made of hostname & port is returned.
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func (cf *AerospikeClientFactory) GetHosts() []*aerospike.Host {
+	if len(cf.hosts) == 0 {
+		return []*aerospike.Host{aerospike.NewHost(cf.hostname, cf.port)}
+	}
+
+	return cf.hosts
+}
+
 // Sets Aerospike DB client policy [aerospike.ClientPolicy].
 //
 // [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
@@ -68,7 +123,41 @@ func (cf *AerospikeClientFactory) GetClientPolicy() *aerospike.ClientPolicy {
 	return cf.policy
 }
 
+// Sets PEM file references [aerofactory.TLSFiles] TLS config of client policy is built from.
+// TLS config is loaded at [AerospikeClientFactory.BuildClient], unless client policy already has one.
+func (cf *AerospikeClientFactory) SetTLSFiles(files *TLSFiles) {
+	cf.tlsFiles = files
+}
+
+// Returns PEM file references [aerofactory.TLSFiles] or nil, if they were not set.
+func (cf *AerospikeClientFactory) GetTLSFiles() *TLSFiles {
+	return cf.tlsFiles
+}
+
+// Sets client mode (See: [Mode]).
+func (cf *AerospikeClientFactory) SetMode(mode Mode) {
+	cf.mode = mode
+}
+
+// Returns client mode. [ModeReadWrite] is returned, if mode was not set.
+func (cf *AerospikeClientFactory) GetMode() Mode {
+	if cf.mode == "" {
+		return ModeReadWrite
+	}
+
+	return cf.mode
+}
+
 // Builds Aerospike DB client [aerospike.Client].
+// [aerospike.Client] cannot refuse writes, so in [ModeReadOnly] it is not built & [ReadOnlyError] is returned,
+// as well as by every path built on it (lazy, namespace-bound, registry, shared, reloaded & traced clients).
+// Read-only clients are built with [AerospikeClientFactory.Build] & [AerospikeClientFactory.BuildReadOnlyClient].
+// If preflight checks were set (See: [Preflight]), they are run after connecting & [PreflightError] is returned on failure.
+// If warm-up was set (See: [WarmUp]), it is run afterwards (See: [AerospikeClientFactory.BuildClientWithWarmUpReport]).
+// If hooks were added (See: [Hooks]), they are called around the build.
+// In-memory factory cannot build [aerospike.Client] (See: [AerospikeClientFactory.Build]).
+//
+// If seed hosts were set, client is created using [aerospike.NewClientWithPolicyAndHost].
 // If [aerospike.ClientPolicy] was parsed from [aerourl.AerospikeURL],
 // client is created using [aerospike.NewClientWithPolicy], otherwise it is created using [aerospike.NewClient].
 //
@@ -76,10 +165,142 @@ func (cf *AerospikeClientFactory) GetClientPolicy() *aerospike.ClientPolicy {
 // [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
 // [aerospike.NewClientWithPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#NewClientWithPolicy
 // [aerospike.NewClient]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#NewClient
+// [aerospike.NewClientWithPolicyAndHost]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#NewClientWithPolicyAndHost
 func (cf *AerospikeClientFactory) BuildClient() (*aerospike.Client, aerospike.Error) {
-	if cf.policy == nil {
+	if cf.GetMode() == ModeReadOnly {
+		return nil, newReadOnlyError("BuildClient")
+	}
+
+	cf.warnUnappliedSettings()
+
+	return cf.buildClient()
+}
+
+// Logs settings enforced by client wrappers only, which [aerospike.Client] built by
+// [AerospikeClientFactory.BuildClient] is not wrapped into.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) warnUnappliedSettings() {
+	if cf.chaos != nil {
+		cf.log(slog.LevelWarn, "aerospike chaos is not injected into aerospike.Client, use Build")
+	}
+
+	if cf.breaker != nil {
+		cf.log(slog.LevelWarn, "aerospike circuit breaker is not applied to aerospike.Client, use Build")
+	}
+
+	if cf.rateLimits != nil {
+		cf.log(slog.LevelWarn, "aerospike rate limits are not applied to aerospike.Client, use Build")
+	}
+}
+
+// Builds Aerospike DB client [aerospike.Client] (See: [AerospikeClientFactory.BuildClient])
+// & returns it along with report of its warm-up or nil report, if warm-up was not set.
+// Warm-up failures do not fail client build, so the report is the place to check them.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) BuildClientWithWarmUpReport() (*aerospike.Client, *WarmUpReport, aerospike.Error) {
+	if cf.GetMode() == ModeReadOnly {
+		return nil, nil, newReadOnlyError("BuildClientWithWarmUpReport")
+	}
+
+	cf.warnUnappliedSettings()
+
+	return cf.buildClientWithWarmUpReport()
+}
+
+// Builds Aerospike DB client [aerospike.Client] regardless of client mode, runs preflight checks & warm-up, if set.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) buildClient() (*aerospike.Client, aerospike.Error) {
+	client, _, err := cf.buildClientWithWarmUpReport()
+	return client, err
+}
+
+// Builds Aerospike DB client [aerospike.Client] & returns it along with its warm-up report.
+// Client failing preflight checks is closed.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) buildClientWithWarmUpReport() (*aerospike.Client, *WarmUpReport, aerospike.Error) {
+	if cf.inMemory {
+		return nil, nil, newFactoryError(types.PARAMETER_ERROR, ErrInMemory)
+	}
+
+	cf.bridgeClientLogger()
+	cf.log(slog.LevelInfo, "aerospike client connecting")
+
+	started := time.Now()
+
+	client, err := cf.connect()
+	if err != nil {
+		cf.log(slog.LevelError, "aerospike client connection failed", "error", err)
+		cf.runOnBuildError(err)
+		return nil, nil, err
+	}
+
+	cf.log(slog.LevelInfo, "aerospike client connected", "nodes", len(client.GetNodes()), "duration", time.Since(started))
+
+	if err := cf.runPreflight(client); err != nil {
+		cf.log(slog.LevelError, "aerospike preflight checks failed", "error", err)
+		client.Close()
+		cf.runOnBuildError(err)
+		return nil, nil, err
+	}
+
+	report := cf.runWarmUp(client)
+	cf.runAfterBuild(client)
+
+	return client, report, nil
+}
+
+// Connects Aerospike DB client [aerospike.Client].
+// If hooks were added, client is connected with client policy & seed hosts passed through [Hooks.BeforeBuild].
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) connect() (*aerospike.Client, aerospike.Error) {
+	policy, err := cf.resolveClientPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cf.hooks) > 0 {
+		policy, hosts, err := cf.runBeforeBuild(policy)
+		if err != nil {
+			return nil, err
+		}
+
+		return aerospike.NewClientWithPolicyAndHost(policy, hosts...)
+	}
+
+	if len(cf.hosts) > 0 {
+		return aerospike.NewClientWithPolicyAndHost(policy, cf.hosts...)
+	}
+
+	if policy == nil {
 		return aerospike.NewClient(cf.hostname, cf.port)
 	}
 
-	return aerospike.NewClientWithPolicy(cf.policy, cf.hostname, cf.port)
+	return aerospike.NewClientWithPolicy(policy, cf.hostname, cf.port)
+}
+
+// Returns client policy to build client with.
+// If TLS files are set & client policy has no TLS config, a copy of client policy with loaded TLS config is returned.
+func (cf *AerospikeClientFactory) resolveClientPolicy() (*aerospike.ClientPolicy, aerospike.Error) {
+	if cf.tlsFiles == nil || (cf.policy != nil && cf.policy.TlsConfig != nil) {
+		return cf.policy, nil
+	}
+
+	tlsConfig, err := cf.tlsFiles.Load()
+	if err != nil {
+		return nil, newFactoryError(types.PARAMETER_ERROR, err)
+	}
+
+	policy := aerospike.NewClientPolicy()
+	if cf.policy != nil {
+		policyCopy := *cf.policy
+		policy = &policyCopy
+	}
+
+	policy.TlsConfig = tlsConfig
+	return policy, nil
 }
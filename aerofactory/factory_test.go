@@ -72,6 +72,19 @@ func TestSetGetNamespace(t *testing.T) {
 	}
 }
 
+func TestSetGetSet(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	set := "users"
+
+	factory.SetSet(set)
+	gotSet := factory.GetSet()
+
+	if gotSet != set {
+		t.Errorf("got: %v, want: %v", gotSet, set)
+	}
+}
+
 func TestSetGetClientPolicy(t *testing.T) {
 	factory := &AerospikeClientFactory{}
 
@@ -89,3 +102,39 @@ func TestSetGetClientPolicy(t *testing.T) {
 		t.Errorf("got: %v, want: %v", gotPolicy, policy)
 	}
 }
+
+func TestGetHostsWithoutSeedHosts(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+
+	hosts := factory.GetHosts()
+	if len(hosts) != 1 {
+		t.Fatalf("got: %v, want: 1 host", len(hosts))
+	}
+
+	if hosts[0].Name != "127.0.0.1" || hosts[0].Port != 3000 {
+		t.Errorf("got: %v, want: 127.0.0.1:3000", hosts[0])
+	}
+}
+
+func TestSetGetHosts(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	hosts := []*aerospike.Host{
+		aerospike.NewHost("10.0.0.1", 3000),
+		aerospike.NewHost("10.0.0.2", 3100),
+	}
+
+	factory.SetHosts(hosts...)
+	gotHosts := factory.GetHosts()
+
+	if len(gotHosts) != len(hosts) {
+		t.Fatalf("got: %v, want: %v", gotHosts, hosts)
+	}
+
+	for i := range hosts {
+		if gotHosts[i].String() != hosts[i].String() {
+			t.Errorf("got: %v, want: %v", gotHosts[i], hosts[i])
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package aerofactory
+
+import (
+	"fmt"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Serves as callbacks around client creation & teardown, so wrappers (metrics, tracing, audit)
+// can be attached without replacing [AerospikeClientFactory.BuildClient]. Any callback may be nil.
+type Hooks struct {
+	// Called before connecting with a copy of client policy & seed hosts, so both may be mutated for this build only.
+	// Returning error vetoes the build: nothing is connected & [ErrBuildVetoed] is returned.
+	BeforeBuild func(policy *aerospike.ClientPolicy, hosts []*aerospike.Host) error
+
+	// Called with client that connected & passed preflight checks & warm-up.
+	AfterBuild func(client *aerospike.Client)
+
+	// Called with error of vetoed, failed or rejected by preflight checks build.
+	OnBuildError func(err aerospike.Error)
+
+	// Called before client is closed with [AerospikeClientFactory.CloseClient].
+	OnClose func(client *aerospike.Client)
+}
+
+// Adds hooks (See: [Hooks]). Hooks are called in the order they were added.
+func (cf *AerospikeClientFactory) AddHooks(hooks ...Hooks) {
+	cf.hooks = append(cf.hooks, hooks...)
+}
+
+// Returns added hooks or nil, if no hooks were added.
+func (cf *AerospikeClientFactory) GetHooks() []Hooks {
+	return cf.hooks
+}
+
+// Closes client built by the factory, calling [Hooks.OnClose] first.
+func (cf *AerospikeClientFactory) CloseClient(client *aerospike.Client) {
+	for _, hooks := range cf.hooks {
+		if hooks.OnClose != nil {
+			hooks.OnClose(client)
+		}
+	}
+
+	client.Close()
+}
+
+// Returns copies of client policy & seed hosts passed through [Hooks.BeforeBuild].
+// Returns error, if any hook vetoed the build.
+func (cf *AerospikeClientFactory) runBeforeBuild(policy *aerospike.ClientPolicy) (*aerospike.ClientPolicy, []*aerospike.Host, aerospike.Error) {
+	policyCopy := aerospike.NewClientPolicy()
+	if policy != nil {
+		*policyCopy = *policy
+	}
+
+	hosts := []*aerospike.Host{}
+	for _, host := range cf.GetHosts() {
+		hostCopy := *host
+		hosts = append(hosts, &hostCopy)
+	}
+
+	for _, hooks := range cf.hooks {
+		if hooks.BeforeBuild == nil {
+			continue
+		}
+
+		if err := hooks.BeforeBuild(policyCopy, hosts); err != nil {
+			return nil, nil, newFactoryError(types.FAIL_FORBIDDEN, fmt.Errorf("%w: %w", ErrBuildVetoed, err))
+		}
+	}
+
+	return policyCopy, hosts, nil
+}
+
+// Calls [Hooks.AfterBuild] with built client.
+func (cf *AerospikeClientFactory) runAfterBuild(client *aerospike.Client) {
+	for _, hooks := range cf.hooks {
+		if hooks.AfterBuild != nil {
+			hooks.AfterBuild(client)
+		}
+	}
+}
+
+// Calls [Hooks.OnBuildError] with build error.
+func (cf *AerospikeClientFactory) runOnBuildError(err aerospike.Error) {
+	for _, hooks := range cf.hooks {
+		if hooks.OnBuildError != nil {
+			hooks.OnBuildError(err)
+		}
+	}
+}
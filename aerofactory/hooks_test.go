@@ -0,0 +1,125 @@
+package aerofactory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Returns factory of unreachable Aerospike DB failing to connect fast.
+func unreachableFactory() *AerospikeClientFactory {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 1, "aero-namespace-001")
+
+	policy := aerospike.NewClientPolicy()
+	policy.Timeout = 100 * time.Millisecond
+	factory.SetClientPolicy(policy)
+
+	return factory
+}
+
+func TestHooksBeforeBuildVeto(t *testing.T) {
+	factory := unreachableFactory()
+	errAudit := errors.New("audit: cluster is not allowed")
+
+	var buildErrs []aerospike.Error
+	afterBuilds := 0
+
+	factory.AddHooks(Hooks{
+		BeforeBuild: func(policy *aerospike.ClientPolicy, hosts []*aerospike.Host) error {
+			return errAudit
+		},
+		AfterBuild:   func(client *aerospike.Client) { afterBuilds++ },
+		OnBuildError: func(err aerospike.Error) { buildErrs = append(buildErrs, err) },
+	})
+
+	_, err := factory.BuildClient()
+	if !errors.Is(err, ErrBuildVetoed) || !errors.Is(err, errAudit) {
+		t.Fatalf("got: %v, want: error is aerofactory.ErrBuildVetoed & hook error", err)
+	}
+
+	if len(buildErrs) != 1 || buildErrs[0] != err {
+		t.Errorf("got: %v, want: [%v]", buildErrs, err)
+	}
+
+	if afterBuilds != 0 {
+		t.Errorf("got: %v AfterBuild calls, want: 0", afterBuilds)
+	}
+}
+
+func TestHooksBeforeBuildMutatesCopies(t *testing.T) {
+	factory := unreachableFactory()
+
+	var order []string
+	var gotHosts []string
+
+	factory.AddHooks(
+		Hooks{BeforeBuild: func(policy *aerospike.ClientPolicy, hosts []*aerospike.Host) error {
+			order = append(order, "first")
+			policy.Timeout = 50 * time.Millisecond
+			hosts[0].Port = 2
+			return nil
+		}},
+		Hooks{BeforeBuild: func(policy *aerospike.ClientPolicy, hosts []*aerospike.Host) error {
+			order = append(order, "second")
+			if policy.Timeout != 50*time.Millisecond {
+				t.Errorf("got: %v, want: timeout mutated by previous hook", policy.Timeout)
+			}
+
+			gotHosts = append(gotHosts, hosts[0].String())
+			return nil
+		}},
+	)
+
+	if _, err := factory.BuildClient(); err == nil {
+		t.Fatalf("got: %v, want: error != nil", err)
+	}
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("got: %v, want: [first second]", order)
+	}
+
+	if len(gotHosts) != 1 || gotHosts[0] != "127.0.0.1:2" {
+		t.Errorf("got: %v, want: [127.0.0.1:2]", gotHosts)
+	}
+
+	if factory.GetClientPolicy().Timeout != 100*time.Millisecond || factory.GetHosts()[0].Port != 1 {
+		t.Errorf("got: %v, %v, want: factory policy & hosts unchanged", factory.GetClientPolicy().Timeout, factory.GetHosts()[0])
+	}
+}
+
+func TestHooksOnBuildError(t *testing.T) {
+	factory := unreachableFactory()
+
+	var buildErrs []aerospike.Error
+	factory.AddHooks(Hooks{OnBuildError: func(err aerospike.Error) { buildErrs = append(buildErrs, err) }})
+
+	_, err := factory.BuildClient()
+	if err == nil {
+		t.Fatalf("got: %v, want: error != nil", err)
+	}
+
+	if len(buildErrs) != 1 || buildErrs[0] != err {
+		t.Errorf("got: %v, want: [%v]", buildErrs, err)
+	}
+}
+
+func TestHooksOnClose(t *testing.T) {
+	factory := unreachableFactory()
+
+	var closed []*aerospike.Client
+	factory.AddHooks(Hooks{OnClose: func(client *aerospike.Client) { closed = append(closed, client) }})
+
+	client := disconnectedClient(t)
+	factory.CloseClient(client)
+
+	if len(closed) != 1 || closed[0] != client {
+		t.Errorf("got: %v, want: [%v]", closed, client)
+	}
+
+	if client.IsConnected() {
+		t.Error("got: client connected, want: client closed")
+	}
+}
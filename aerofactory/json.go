@@ -0,0 +1,368 @@
+package aerofactory
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Replaces password in marshalled factory, same as [net/url.URL.Redacted] does.
+const redactedPassword = "xxxxx"
+
+// JSON document of [AerospikeClientFactory].
+type factoryJSON struct {
+	Hosts     []hostJSON   `json:"hosts"`
+	Namespace string       `json:"namespace,omitempty"`
+	Set       string       `json:"set,omitempty"`
+	Policy    *policyJSON  `json:"policy"`
+	TLS       *TLSFiles    `json:"tls,omitempty"`
+	Mode      Mode         `json:"mode,omitempty"`
+	Preflight *Preflight   `json:"preflight,omitempty"`
+	WarmUp    *warmUpJSON  `json:"warm_up,omitempty"`
+	InMemory  bool         `json:"in_memory,omitempty"`
+	Chaos     *chaosJSON   `json:"chaos,omitempty"`
+	Breaker   *breakerJSON `json:"breaker,omitempty"`
+	RateLimit *RateLimits  `json:"rate_limit,omitempty"`
+}
+
+// JSON document of [WarmUp].
+type warmUpJSON struct {
+	Count   int          `json:"count,omitempty"`
+	Min     bool         `json:"min,omitempty"`
+	Timeout durationJSON `json:"timeout"`
+}
+
+// JSON document of [Chaos].
+type chaosJSON struct {
+	Latency   durationJSON `json:"latency"`
+	ErrorRate float64      `json:"error_rate,omitempty"`
+	Errors    []ChaosFault `json:"errors,omitempty"`
+	Seed      int64        `json:"seed,omitempty"`
+}
+
+// Converts JSON document into [Chaos].
+func (chaosDoc *chaosJSON) toChaos() *Chaos {
+	return &Chaos{time.Duration(chaosDoc.Latency), chaosDoc.ErrorRate, chaosDoc.Errors, chaosDoc.Seed}
+}
+
+// JSON document of [Breaker].
+type breakerJSON struct {
+	FailureRatio float64      `json:"failure_ratio,omitempty"`
+	Window       durationJSON `json:"window"`
+	Cooldown     durationJSON `json:"cooldown"`
+	MinRequests  int          `json:"min_requests,omitempty"`
+}
+
+// Converts JSON document into [Breaker].
+func (breakerDoc *breakerJSON) toBreaker() *Breaker {
+	return &Breaker{breakerDoc.FailureRatio, time.Duration(breakerDoc.Window), time.Duration(breakerDoc.Cooldown), breakerDoc.MinRequests}
+}
+
+// JSON document of [aerospike.Host].
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+type hostJSON struct {
+	Name    string `json:"name"`
+	Port    int    `json:"port"`
+	TLSName string `json:"tls_name,omitempty"`
+}
+
+// JSON document of [aerospike.ClientPolicy]. Keys are named after URL query parameters.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+type policyJSON struct {
+	AuthMode                    authModeJSON      `json:"auth_mode"`
+	User                        string            `json:"user,omitempty"`
+	Password                    string            `json:"password,omitempty"`
+	ClusterName                 string            `json:"cluster_name,omitempty"`
+	Timeout                     durationJSON      `json:"timeout"`
+	IdleTimeout                 durationJSON      `json:"idle_timeout"`
+	LoginTimeout                durationJSON      `json:"login_timeout"`
+	ConnectionQueueSize         int               `json:"connection_queue_size"`
+	MinConnectionsPerNode       int               `json:"min_connections_per_node"`
+	MaxErrorRate                int               `json:"max_error_rate"`
+	ErrorRateWindow             int               `json:"error_rate_window"`
+	LimitConnectionsToQueueSize bool              `json:"limit_connections_to_queue_size"`
+	OpeningConnectionThreshold  int               `json:"opening_connection_threshold"`
+	FailIfNotConnected          bool              `json:"fail_if_not_connected"`
+	TendInterval                durationJSON      `json:"tend_interval"`
+	IpMap                       map[string]string `json:"ip_map,omitempty"`
+	UseServicesAlternate        bool              `json:"use_services_alternate"`
+	RackAware                   bool              `json:"rack_aware"`
+	RackId                      int               `json:"rack_id"`
+	RackIds                     []int             `json:"rack_ids,omitempty"`
+	IgnoreOtherSubnetAliases    bool              `json:"ignore_subnet_aliases"`
+	SeedOnlyCluster             bool              `json:"seed_only_cluster"`
+}
+
+// [time.Duration] marshalled in human units, e.g. "10s".
+type durationJSON time.Duration
+
+func (duration durationJSON) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(duration).String()), nil
+}
+
+func (duration *durationJSON) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*duration = durationJSON(parsed)
+	return nil
+}
+
+// [aerospike.AuthMode] marshalled the same way as `auth_mode` URL query parameter.
+//
+// [aerospike.AuthMode]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#AuthMode
+type authModeJSON aerospike.AuthMode
+
+func (authMode authModeJSON) MarshalText() ([]byte, error) {
+	switch aerospike.AuthMode(authMode) {
+	case aerospike.AuthModeExternal:
+		return []byte("auth_mode_external"), nil
+	case aerospike.AuthModePKI:
+		return []byte("auth_mode_pki"), nil
+	default:
+		return []byte("auth_mode_internal"), nil
+	}
+}
+
+func (authMode *authModeJSON) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "auth_mode_internal":
+		*authMode = authModeJSON(aerospike.AuthModeInternal)
+	case "auth_mode_external":
+		*authMode = authModeJSON(aerospike.AuthModeExternal)
+	case "auth_mode_pki":
+		*authMode = authModeJSON(aerospike.AuthModePKI)
+	default:
+		return ErrInvalidAuthMode
+	}
+
+	return nil
+}
+
+// Marshals factory into JSON document: hosts, namespace, set, every client policy field & TLS file references.
+// Durations are expressed in human units (e.g. "10s") & password is redacted.
+//
+//	{"hosts": [{"name": "127.0.0.1", "port": 3000}], "namespace": "aero-namespace-001", "policy": {"timeout": "10s", ...}}
+func (cf *AerospikeClientFactory) MarshalJSON() ([]byte, error) {
+	doc := cf.toJSON()
+
+	if doc.Policy.Password != "" {
+		doc.Policy.Password = redactedPassword
+	}
+
+	return json.Marshal(doc)
+}
+
+// Unmarshals factory from JSON document produced by [AerospikeClientFactory.MarshalJSON].
+// Policy fields missing from the document keep their current (or default) values.
+// Redacted password is ignored, so current password is kept.
+func (cf *AerospikeClientFactory) UnmarshalJSON(data []byte) error {
+	doc := cf.toJSON()
+	password := doc.Policy.Password
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	if len(doc.Hosts) == 0 || doc.Hosts[0].Name == "" {
+		return ErrNoHosts
+	}
+
+	if doc.Policy == nil {
+		doc.Policy = (&AerospikeClientFactory{}).toJSON().Policy
+	}
+
+	if doc.Policy.Password == redactedPassword {
+		doc.Policy.Password = password
+	}
+
+	if doc.Chaos != nil {
+		if err := doc.Chaos.toChaos().Validate(); err != nil {
+			return err
+		}
+	}
+
+	if doc.Breaker != nil {
+		if err := doc.Breaker.toBreaker().Validate(); err != nil {
+			return err
+		}
+	}
+
+	if doc.RateLimit != nil {
+		if err := doc.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	cf.fromJSON(doc)
+	return nil
+}
+
+// Converts factory into its JSON document.
+func (cf *AerospikeClientFactory) toJSON() factoryJSON {
+	policy := cf.policy
+	if policy == nil {
+		policy = aerospike.NewClientPolicy()
+	}
+
+	hosts := []hostJSON{}
+	if cf.hostname != "" || len(cf.hosts) > 0 {
+		for _, host := range cf.GetHosts() {
+			hosts = append(hosts, hostJSON{host.Name, host.Port, host.TLSName})
+		}
+	}
+
+	var tlsFiles *TLSFiles
+	if cf.tlsFiles != nil {
+		tlsFilesCopy := *cf.tlsFiles
+		tlsFiles = &tlsFilesCopy
+	}
+
+	var preflight *Preflight
+	if cf.preflight != nil {
+		preflight = &Preflight{cf.preflight.VerifyNamespace, append([]string(nil), cf.preflight.RequireRoles...)}
+	}
+
+	var warmUp *warmUpJSON
+	if cf.warmUp != nil {
+		warmUp = &warmUpJSON{cf.warmUp.Count, cf.warmUp.Min, durationJSON(cf.warmUp.Timeout)}
+	}
+
+	var chaos *chaosJSON
+	if cf.chaos != nil {
+		chaos = &chaosJSON{durationJSON(cf.chaos.Latency), cf.chaos.ErrorRate, append([]ChaosFault(nil), cf.chaos.Errors...), cf.chaos.Seed}
+	}
+
+	var breaker *breakerJSON
+	if cf.breaker != nil {
+		breaker = &breakerJSON{cf.breaker.FailureRatio, durationJSON(cf.breaker.Window), durationJSON(cf.breaker.Cooldown), cf.breaker.MinRequests}
+	}
+
+	var rateLimits *RateLimits
+	if cf.rateLimits != nil {
+		rateLimits = cf.rateLimits.clone()
+	}
+
+	var ipMap map[string]string
+	if policy.IpMap != nil {
+		ipMap = make(map[string]string, len(policy.IpMap))
+		for from, to := range policy.IpMap {
+			ipMap[from] = to
+		}
+	}
+
+	// Copied, so unmarshalling into the document never mutates current policy
+	rackIds := append([]int(nil), policy.RackIds...)
+
+	return factoryJSON{
+		Hosts:     hosts,
+		Namespace: cf.namespace,
+		Set:       cf.set,
+		TLS:       tlsFiles,
+		Mode:      cf.mode,
+		Preflight: preflight,
+		WarmUp:    warmUp,
+		InMemory:  cf.inMemory,
+		Chaos:     chaos,
+		Breaker:   breaker,
+		RateLimit: rateLimits,
+		Policy: &policyJSON{
+			AuthMode:                    authModeJSON(policy.AuthMode),
+			User:                        policy.User,
+			Password:                    policy.Password,
+			ClusterName:                 policy.ClusterName,
+			Timeout:                     durationJSON(policy.Timeout),
+			IdleTimeout:                 durationJSON(policy.IdleTimeout),
+			LoginTimeout:                durationJSON(policy.LoginTimeout),
+			ConnectionQueueSize:         policy.ConnectionQueueSize,
+			MinConnectionsPerNode:       policy.MinConnectionsPerNode,
+			MaxErrorRate:                policy.MaxErrorRate,
+			ErrorRateWindow:             policy.ErrorRateWindow,
+			LimitConnectionsToQueueSize: policy.LimitConnectionsToQueueSize,
+			OpeningConnectionThreshold:  policy.OpeningConnectionThreshold,
+			FailIfNotConnected:          policy.FailIfNotConnected,
+			TendInterval:                durationJSON(policy.TendInterval),
+			IpMap:                       ipMap,
+			UseServicesAlternate:        policy.UseServicesAlternate,
+			RackAware:                   policy.RackAware,
+			RackId:                      policy.RackId,
+			RackIds:                     rackIds,
+			IgnoreOtherSubnetAliases:    policy.IgnoreOtherSubnetAliases,
+			SeedOnlyCluster:             policy.SeedOnlyCluster,
+		},
+	}
+}
+
+// Applies JSON document to factory.
+// The first host is used as hostname & port, seed hosts are set only if there are several of them.
+func (cf *AerospikeClientFactory) fromJSON(doc factoryJSON) {
+	hosts := make([]*aerospike.Host, 0, len(doc.Hosts))
+	for _, host := range doc.Hosts {
+		hosts = append(hosts, &aerospike.Host{Name: host.Name, Port: host.Port, TLSName: host.TLSName})
+	}
+
+	cf.SetAddress(hosts[0].Name, hosts[0].Port, doc.Namespace)
+	cf.SetSet(doc.Set)
+
+	cf.hosts = nil
+	if len(hosts) > 1 || hosts[0].TLSName != "" {
+		cf.SetHosts(hosts...)
+	}
+
+	policy := aerospike.NewClientPolicy()
+	if cf.policy != nil {
+		policy.TlsConfig = cf.policy.TlsConfig
+	}
+
+	policy.AuthMode = aerospike.AuthMode(doc.Policy.AuthMode)
+	policy.User = doc.Policy.User
+	policy.Password = doc.Policy.Password
+	policy.ClusterName = doc.Policy.ClusterName
+	policy.Timeout = time.Duration(doc.Policy.Timeout)
+	policy.IdleTimeout = time.Duration(doc.Policy.IdleTimeout)
+	policy.LoginTimeout = time.Duration(doc.Policy.LoginTimeout)
+	policy.ConnectionQueueSize = doc.Policy.ConnectionQueueSize
+	policy.MinConnectionsPerNode = doc.Policy.MinConnectionsPerNode
+	policy.MaxErrorRate = doc.Policy.MaxErrorRate
+	policy.ErrorRateWindow = doc.Policy.ErrorRateWindow
+	policy.LimitConnectionsToQueueSize = doc.Policy.LimitConnectionsToQueueSize
+	policy.OpeningConnectionThreshold = doc.Policy.OpeningConnectionThreshold
+	policy.FailIfNotConnected = doc.Policy.FailIfNotConnected
+	policy.TendInterval = time.Duration(doc.Policy.TendInterval)
+	policy.IpMap = doc.Policy.IpMap
+	policy.UseServicesAlternate = doc.Policy.UseServicesAlternate
+	policy.RackAware = doc.Policy.RackAware
+	policy.RackId = doc.Policy.RackId
+	policy.RackIds = doc.Policy.RackIds
+	policy.IgnoreOtherSubnetAliases = doc.Policy.IgnoreOtherSubnetAliases
+	policy.SeedOnlyCluster = doc.Policy.SeedOnlyCluster
+
+	cf.SetClientPolicy(policy)
+	cf.SetTLSFiles(doc.TLS)
+	cf.SetMode(doc.Mode)
+	cf.SetPreflight(doc.Preflight)
+	cf.SetInMemory(doc.InMemory)
+
+	cf.warmUp = nil
+	if doc.WarmUp != nil {
+		cf.SetWarmUp(&WarmUp{doc.WarmUp.Count, doc.WarmUp.Min, time.Duration(doc.WarmUp.Timeout)})
+	}
+
+	// Chaos is still applied only if allowed, see AerospikeClientFactory.AllowChaos
+	cf.chaos = nil
+	if doc.Chaos != nil {
+		cf.SetChaos(doc.Chaos.toChaos())
+	}
+
+	cf.breaker = nil
+	if doc.Breaker != nil {
+		cf.SetBreaker(doc.Breaker.toBreaker())
+	}
+
+	cf.SetRateLimits(doc.RateLimit)
+}
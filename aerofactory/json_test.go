@@ -0,0 +1,206 @@
+package aerofactory
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+	factory.SetTLSFiles(&TLSFiles{CAFile: "/etc/aerospike/ca.pem"})
+
+	policy := aerospike.NewClientPolicy()
+	policy.User = "aero-user-001"
+	policy.Password = "aerouser001passw"
+	policy.Timeout = 10 * time.Second
+	policy.AuthMode = aerospike.AuthModeExternal
+	factory.SetClientPolicy(policy)
+
+	data, err := json.Marshal(factory)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	for _, want := range []string{`"name":"127.0.0.1"`, `"namespace":"aero-namespace-001"`, `"timeout":"10s"`, `"auth_mode":"auth_mode_external"`, `"ca_file":"/etc/aerospike/ca.pem"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("got: %s, want: %s", data, want)
+		}
+	}
+
+	if strings.Contains(string(data), "aerouser001passw") {
+		t.Errorf("got: %s, want: password redacted", data)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	data := `{
+		"hosts": [{"name": "10.0.0.1", "port": 3000}, {"name": "10.0.0.2", "port": 3000}],
+		"namespace": "aero-namespace-001",
+		"policy": {"user": "aero-user-001", "password": "aerouser001passw", "idle_timeout": "3s", "max_error_rate": 50}
+	}`
+
+	factory := &AerospikeClientFactory{}
+	if err := json.Unmarshal([]byte(data), factory); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if factory.GetHostname() != "10.0.0.1" || len(factory.GetHosts()) != 2 {
+		t.Errorf("got: %v, want: 2 hosts starting with 10.0.0.1", factory.GetHosts())
+	}
+
+	policy := factory.GetClientPolicy()
+	if policy.IdleTimeout != 3*time.Second || policy.MaxErrorRate != 50 || policy.Password != "aerouser001passw" {
+		t.Errorf("got: %v, want: policy from document", policy)
+	}
+
+	if policy.Timeout != aerospike.NewClientPolicy().Timeout {
+		t.Errorf("got: %v, want: default timeout", policy.Timeout)
+	}
+}
+
+func TestJSONRoundTripKeepsPassword(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+
+	policy := aerospike.NewClientPolicy()
+	policy.Password = "aerouser001passw"
+	factory.SetClientPolicy(policy)
+
+	data, _ := json.Marshal(factory)
+	if err := json.Unmarshal(data, factory); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if factory.GetClientPolicy().Password != "aerouser001passw" {
+		t.Errorf("got: %v, want: password kept", factory.GetClientPolicy().Password)
+	}
+}
+
+func TestUnmarshalJSONNoHosts(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	err := json.Unmarshal([]byte(`{"namespace": "aero-namespace-001"}`), factory)
+	if !errors.Is(err, ErrNoHosts) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNoHosts", err)
+	}
+}
+
+func TestUnmarshalJSONInvalidAuthMode(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	err := json.Unmarshal([]byte(`{"hosts": [{"name": "127.0.0.1", "port": 3000}], "policy": {"auth_mode": "ldap"}}`), factory)
+	if !errors.Is(err, ErrInvalidAuthMode) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrInvalidAuthMode", err)
+	}
+}
+
+func TestUnmarshalJSONInvalidChaos(t *testing.T) {
+	tests := map[string]error{
+		`{"latency": "-1s"}`:              ErrInvalidChaosLatency,
+		`{"error_rate": 2}`:               ErrInvalidChaosErrorRate,
+		`{"errors": ["timeout", "oops"]}`: ErrInvalidChaosErrors,
+	}
+
+	for chaos, want := range tests {
+		factory := &AerospikeClientFactory{}
+
+		err := json.Unmarshal([]byte(`{"hosts": [{"name": "127.0.0.1", "port": 3000}], "chaos": `+chaos+`}`), factory)
+		if !errors.Is(err, want) || factory.GetChaos() != nil {
+			t.Errorf("got: %v, %v, want: error is %v & chaos = nil", factory.GetChaos(), err, want)
+		}
+	}
+}
+
+func TestUnmarshalJSONInvalidBreaker(t *testing.T) {
+	tests := map[string]error{
+		`{"failure_ratio": 1.5}`: ErrInvalidBreakerFailureRatio,
+		`{"window": "-10s"}`:     ErrInvalidBreakerWindow,
+		`{"cooldown": "-5s"}`:    ErrInvalidBreakerCooldown,
+		`{"min_requests": -1}`:   ErrInvalidBreakerMinRequests,
+	}
+
+	for breaker, want := range tests {
+		factory := &AerospikeClientFactory{}
+
+		err := json.Unmarshal([]byte(`{"hosts": [{"name": "127.0.0.1", "port": 3000}], "breaker": `+breaker+`}`), factory)
+		if !errors.Is(err, want) || factory.GetBreaker() != nil {
+			t.Errorf("got: %v, %v, want: error is %v & breaker = nil", factory.GetBreaker(), err, want)
+		}
+	}
+}
+
+func TestUnmarshalJSONInvalidRateLimits(t *testing.T) {
+	tests := map[string]error{
+		`{"all": {"rate": 0}}`:                    ErrInvalidRateLimit,
+		`{"classes": {"write": {"rate": -1}}}`:    ErrInvalidRateLimit,
+		`{"all": {"rate": 5000, "burst": -1}}`:    ErrInvalidRateBurst,
+		`{"all": {"rate": 5000}, "mode": "drop"}`: ErrInvalidRateLimitMode,
+	}
+
+	for rateLimits, want := range tests {
+		factory := &AerospikeClientFactory{}
+
+		err := json.Unmarshal([]byte(`{"hosts": [{"name": "127.0.0.1", "port": 3000}], "rate_limit": `+rateLimits+`}`), factory)
+		if !errors.Is(err, want) || factory.GetRateLimits() != nil {
+			t.Errorf("got: %v, %v, want: error is %v & rate limits = nil", factory.GetRateLimits(), err, want)
+		}
+	}
+}
+
+func TestJSONRoundTripClientOptions(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+	factory.SetSet("users")
+	factory.SetMode(ModeReadOnly)
+	factory.SetPreflight(&Preflight{VerifyNamespace: true, RequireRoles: []string{"read"}})
+	factory.SetWarmUp(&WarmUp{Min: true, Timeout: 3 * time.Second})
+	factory.SetInMemory(true)
+	factory.SetChaos(&Chaos{Latency: 20 * time.Millisecond, ErrorRate: 0.01, Errors: []ChaosFault{ChaosFaultTimeout}, Seed: 42})
+	factory.SetBreaker(&Breaker{FailureRatio: 0.25, Window: 30 * time.Second, Cooldown: 2 * time.Second, MinRequests: 50})
+	factory.SetRateLimits(&RateLimits{All: &RateLimit{Rate: 5000, Burst: 500}, Classes: map[OperationClass]*RateLimit{OperationWrite: {Rate: 1000}}, Mode: RateLimitFailFast})
+
+	data, err := json.Marshal(factory)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	decoded := &AerospikeClientFactory{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if decoded.GetSet() != "users" || decoded.GetMode() != ModeReadOnly || !decoded.GetInMemory() {
+		t.Errorf("got: %v, %v, %v, want: users, readonly, in-memory", decoded.GetSet(), decoded.GetMode(), decoded.GetInMemory())
+	}
+
+	if preflight := decoded.GetPreflight(); preflight == nil || !preflight.VerifyNamespace || len(preflight.RequireRoles) != 1 {
+		t.Errorf("got: %v, want: preflight from document", preflight)
+	}
+
+	if warmUp := decoded.GetWarmUp(); warmUp == nil || !warmUp.Min || warmUp.Timeout != 3*time.Second {
+		t.Errorf("got: %v, want: warm-up from document", warmUp)
+	}
+
+	if chaos := decoded.GetChaos(); chaos == nil || chaos.Latency != 20*time.Millisecond || chaos.ErrorRate != 0.01 || len(chaos.Errors) != 1 || chaos.Seed != 42 {
+		t.Errorf("got: %v, want: chaos from document", chaos)
+	}
+
+	if decoded.ChaosAllowed() {
+		t.Error("got: chaos allowed, want: chaos not allowed by document")
+	}
+
+	if breaker := decoded.GetBreaker(); breaker == nil || *breaker != (Breaker{0.25, 30 * time.Second, 2 * time.Second, 50}) {
+		t.Errorf("got: %v, want: breaker from document", breaker)
+	}
+
+	if !reflect.DeepEqual(decoded.GetRateLimits(), factory.GetRateLimits()) {
+		t.Errorf("got: %+v, want: %+v", decoded.GetRateLimits(), factory.GetRateLimits())
+	}
+}
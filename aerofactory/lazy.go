@@ -0,0 +1,124 @@
+package aerofactory
+
+import (
+	"sync"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Serves as a handle to [aerospike.Client] that connects on first use instead of at build time,
+// so services can start while Aerospike DB is briefly unavailable.
+// If connecting fails, the next [LazyClient.Client] call retries. Safe for concurrent use.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+type LazyClient struct {
+	mu      sync.Mutex
+	build   func() (*aerospike.Client, aerospike.Error)
+	close   func(client *aerospike.Client)
+	client  *aerospike.Client
+	err     aerospike.Error
+	attempt *lazyAttempt
+	closed  bool
+}
+
+// Holds connection attempt in progress, shared by concurrent [LazyClient.Client] callers.
+// Client & err are set before done is closed.
+type lazyAttempt struct {
+	done   chan struct{}
+	client *aerospike.Client
+	err    aerospike.Error
+}
+
+// Returns [LazyClient] building client with [AerospikeClientFactory.BuildClient] on first use.
+// Nothing is connected until [LazyClient.Client] is called.
+func (cf *AerospikeClientFactory) BuildLazyClient() *LazyClient {
+	return &LazyClient{build: cf.BuildClient, close: cf.CloseClient}
+}
+
+// Returns connected [aerospike.Client], connecting on first call.
+// If previous attempt failed, connecting is retried.
+// Returns error, if connecting fails or lazy client was closed.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (lazy *LazyClient) Client() (*aerospike.Client, aerospike.Error) {
+	lazy.mu.Lock()
+
+	if lazy.closed {
+		lazy.mu.Unlock()
+		return nil, newFactoryError(types.ILLEGAL_STATE, ErrLazyClientClosed)
+	}
+
+	if lazy.client != nil {
+		client := lazy.client
+		lazy.mu.Unlock()
+		return client, nil
+	}
+
+	// Another caller is connecting, wait for its result
+	if attempt := lazy.attempt; attempt != nil {
+		lazy.mu.Unlock()
+		<-attempt.done
+		return attempt.client, attempt.err
+	}
+
+	attempt := &lazyAttempt{done: make(chan struct{})}
+	lazy.attempt = attempt
+	lazy.mu.Unlock()
+
+	// Connect without holding the lock, so Ready, Err & Close do not wait for it
+	client, err := lazy.build()
+
+	lazy.mu.Lock()
+	defer lazy.mu.Unlock()
+	defer close(attempt.done)
+
+	lazy.attempt = nil
+
+	if lazy.closed {
+		if client != nil {
+			lazy.close(client)
+		}
+
+		attempt.err = newFactoryError(types.ILLEGAL_STATE, ErrLazyClientClosed)
+		return nil, attempt.err
+	}
+
+	lazy.client, lazy.err = client, err
+	attempt.client, attempt.err = client, err
+	return client, err
+}
+
+// Reports whether client is connected & ready to be used. Never triggers connecting.
+func (lazy *LazyClient) Ready() bool {
+	lazy.mu.Lock()
+	defer lazy.mu.Unlock()
+
+	return lazy.client != nil && lazy.client.IsConnected()
+}
+
+// Returns error of the last failed connection attempt or nil, if there was no attempt or it succeeded.
+func (lazy *LazyClient) Err() error {
+	lazy.mu.Lock()
+	defer lazy.mu.Unlock()
+
+	if lazy.err == nil {
+		return nil
+	}
+
+	return lazy.err
+}
+
+// Closes client (See: [AerospikeClientFactory.CloseClient]), if it was connected. Lazy client cannot be used after closing.
+// Client of connection attempt in progress is closed as soon as the attempt finishes.
+func (lazy *LazyClient) Close() {
+	lazy.mu.Lock()
+	defer lazy.mu.Unlock()
+
+	if lazy.client != nil {
+		lazy.close(lazy.client)
+		lazy.client = nil
+	}
+
+	lazy.closed = true
+}
@@ -0,0 +1,124 @@
+package aerofactory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+func TestLazyClientDoesNotConnectOnBuild(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 1, "aero-namespace-001")
+
+	lazy := factory.BuildLazyClient()
+
+	if lazy.Ready() {
+		t.Error("got: Ready() = true, want: false")
+	}
+
+	if lazy.Err() != nil {
+		t.Errorf("got: %v, want: Err() = nil before first use", lazy.Err())
+	}
+}
+
+func TestLazyClientRetriesAfterFailure(t *testing.T) {
+	attempts := 0
+	client := &aerospike.Client{}
+
+	lazy := &LazyClient{build: func() (*aerospike.Client, aerospike.Error) {
+		attempts++
+		if attempts == 1 {
+			return nil, newFactoryError(types.NETWORK_ERROR, errors.New("connection refused"))
+		}
+
+		return client, nil
+	}}
+
+	if _, err := lazy.Client(); err == nil {
+		t.Fatal("got: error = nil, want: error != nil")
+	}
+
+	if lazy.Err() == nil {
+		t.Error("got: Err() = nil, want: Err() != nil after failure")
+	}
+
+	gotClient, err := lazy.Client()
+	if err != nil || gotClient != client {
+		t.Fatalf("got: %v, %v, want: client after retry", gotClient, err)
+	}
+
+	if lazy.Err() != nil {
+		t.Errorf("got: %v, want: Err() = nil after success", lazy.Err())
+	}
+
+	lazy.Client()
+	if attempts != 2 {
+		t.Errorf("got: %v attempts, want: 2", attempts)
+	}
+}
+
+func TestLazyClientConnectionError(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 1, "aero-namespace-001")
+
+	lazy := factory.BuildLazyClient()
+
+	if _, err := lazy.Client(); err == nil || !err.Matches(types.INVALID_NODE_ERROR) {
+		t.Errorf("got: %v, want: INVALID_NODE_ERROR", err)
+	}
+}
+
+func TestLazyClientClosed(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 1, "aero-namespace-001")
+
+	lazy := factory.BuildLazyClient()
+	lazy.Close()
+
+	if _, err := lazy.Client(); !errors.Is(err, ErrLazyClientClosed) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrLazyClientClosed", err)
+	}
+}
+
+func TestLazyClientDoesNotBlockWhileConnecting(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	builds := 0
+
+	lazy := &LazyClient{build: func() (*aerospike.Client, aerospike.Error) {
+		builds++
+		close(started)
+		<-release
+		return &aerospike.Client{}, nil
+	}}
+
+	results := make(chan *aerospike.Client, 2)
+	go func() {
+		client, _ := lazy.Client()
+		results <- client
+	}()
+	<-started
+
+	go func() {
+		client, _ := lazy.Client()
+		results <- client
+	}()
+
+	ready := make(chan bool)
+	go func() { ready <- lazy.Ready() && lazy.Err() == nil }()
+
+	select {
+	case <-ready:
+	case <-time.After(time.Second):
+		t.Fatal("got: Ready() blocked, want: Ready() returns while connecting")
+	}
+
+	close(release)
+	first, second := <-results, <-results
+
+	if first == nil || first != second || builds != 1 {
+		t.Errorf("got: %p, %p, %v builds, want: connection attempt shared", first, second, builds)
+	}
+}
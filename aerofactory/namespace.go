@@ -0,0 +1,175 @@
+package aerofactory
+
+import (
+	"fmt"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Serves as [aerospike.Client] wrapper bound to the namespace (& optional default set) parsed from Aerospike URL,
+// so call sites do not hard-code namespace. Keys & statements targeting other namespaces are rejected
+// before reaching the server.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+type NamespaceClient struct {
+	client    *aerospike.Client
+	namespace string
+	set       string
+
+	close func(client *aerospike.Client)
+}
+
+// Wraps [aerospike.Client] into [NamespaceClient] bound to namespace & default set.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func NewNamespaceClient(client *aerospike.Client, namespace string, set string) *NamespaceClient {
+	return &NamespaceClient{client: client, namespace: namespace, set: set, close: (*aerospike.Client).Close}
+}
+
+// Builds client (See: [AerospikeClientFactory.BuildClient]) & binds it to factory namespace & default set.
+// Returns error, if factory has no namespace or client cannot be built.
+func (cf *AerospikeClientFactory) BuildNamespaceClient() (*NamespaceClient, aerospike.Error) {
+	if cf.namespace == "" {
+		return nil, newFactoryError(types.PARAMETER_ERROR, ErrEmptyNamespace)
+	}
+
+	client, err := cf.BuildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	nsClient := NewNamespaceClient(client, cf.namespace, cf.set)
+	nsClient.close = cf.CloseClient
+
+	return nsClient, nil
+}
+
+// Returns namespace client is bound to.
+func (nsClient *NamespaceClient) Namespace() string {
+	return nsClient.namespace
+}
+
+// Returns default set used for keys & statements without set.
+func (nsClient *NamespaceClient) Set() string {
+	return nsClient.set
+}
+
+// Returns underlying [aerospike.Client] for operations not covered by the wrapper.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (nsClient *NamespaceClient) Client() *aerospike.Client {
+	return nsClient.client
+}
+
+// Creates [aerospike.Key] in bound namespace. If set is empty, default set is used.
+//
+// [aerospike.Key]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Key
+func (nsClient *NamespaceClient) Key(set string, userKey interface{}) (*aerospike.Key, aerospike.Error) {
+	if set == "" {
+		set = nsClient.set
+	}
+
+	return aerospike.NewKey(nsClient.namespace, set, userKey)
+}
+
+// Reads record (See: [aerospike.Client.Get]). Rejects key of another namespace.
+//
+// [aerospike.Client.Get]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Get
+func (nsClient *NamespaceClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	if err := nsClient.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	return nsClient.client.Get(policy, key, binNames...)
+}
+
+// Writes record (See: [aerospike.Client.Put]). Rejects key of another namespace.
+//
+// [aerospike.Client.Put]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Put
+func (nsClient *NamespaceClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := nsClient.checkKey(key); err != nil {
+		return err
+	}
+
+	return nsClient.client.Put(policy, key, binMap)
+}
+
+// Deletes record (See: [aerospike.Client.Delete]). Rejects key of another namespace.
+//
+// [aerospike.Client.Delete]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Delete
+func (nsClient *NamespaceClient) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	if err := nsClient.checkKey(key); err != nil {
+		return false, err
+	}
+
+	return nsClient.client.Delete(policy, key)
+}
+
+// Performs operations on record (See: [aerospike.Client.Operate]). Rejects key of another namespace.
+//
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+func (nsClient *NamespaceClient) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error) {
+	if err := nsClient.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	return nsClient.client.Operate(policy, key, operations...)
+}
+
+// Queries bound namespace (See: [aerospike.Client.Query]).
+// Empty statement namespace & set are filled with bound ones, statement of another namespace is rejected.
+//
+// [aerospike.Client.Query]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Query
+func (nsClient *NamespaceClient) Query(policy *aerospike.QueryPolicy, statement *aerospike.Statement) (*aerospike.Recordset, aerospike.Error) {
+	bound, err := nsClient.bindStatement(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	return nsClient.client.Query(policy, bound)
+}
+
+// Closes underlying client. Client built with [AerospikeClientFactory.BuildNamespaceClient]
+// is closed with [AerospikeClientFactory.CloseClient].
+func (nsClient *NamespaceClient) Close() {
+	nsClient.close(nsClient.client)
+}
+
+// Returns error, if key targets a namespace other than the bound one.
+func (nsClient *NamespaceClient) checkKey(key *aerospike.Key) aerospike.Error {
+	if key != nil && key.Namespace() != nsClient.namespace {
+		return nsClient.mismatch(key.Namespace())
+	}
+
+	return nil
+}
+
+// Returns copy of statement with empty namespace & set filled with bound ones, so statement passed by caller is kept as is.
+// Returns error, if statement is nil or targets a namespace other than the bound one.
+func (nsClient *NamespaceClient) bindStatement(statement *aerospike.Statement) (*aerospike.Statement, aerospike.Error) {
+	if statement == nil {
+		return nil, newFactoryError(types.PARAMETER_ERROR, ErrNilStatement)
+	}
+
+	bound := *statement
+
+	if bound.Namespace == "" {
+		bound.Namespace = nsClient.namespace
+	}
+
+	if bound.Namespace != nsClient.namespace {
+		return nil, nsClient.mismatch(bound.Namespace)
+	}
+
+	if bound.SetName == "" {
+		bound.SetName = nsClient.set
+	}
+
+	return &bound, nil
+}
+
+// Returns [ErrNamespaceMismatch] wrapped into [FactoryError].
+func (nsClient *NamespaceClient) mismatch(namespace string) aerospike.Error {
+	return newFactoryError(types.PARAMETER_ERROR, fmt.Errorf("%w: got %s, want %s", ErrNamespaceMismatch, namespace, nsClient.namespace))
+}
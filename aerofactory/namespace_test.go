@@ -0,0 +1,88 @@
+package aerofactory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+func TestNamespaceClientKey(t *testing.T) {
+	nsClient := NewNamespaceClient(&aerospike.Client{}, "aero-namespace-001", "users")
+
+	key, err := nsClient.Key("", "user-001")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if key.Namespace() != "aero-namespace-001" || key.SetName() != "users" {
+		t.Errorf("got: %v/%v, want: aero-namespace-001/users", key.Namespace(), key.SetName())
+	}
+
+	key, _ = nsClient.Key("sessions", 42)
+	if key.SetName() != "sessions" {
+		t.Errorf("got: %v, want: sessions", key.SetName())
+	}
+}
+
+func TestNamespaceClientRejectsOtherNamespace(t *testing.T) {
+	nsClient := NewNamespaceClient(&aerospike.Client{}, "aero-namespace-001", "")
+	key, _ := aerospike.NewKey("aero-namespace-002", "users", "user-001")
+
+	if _, err := nsClient.Get(nil, key); !errors.Is(err, ErrNamespaceMismatch) || !err.Matches(types.PARAMETER_ERROR) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNamespaceMismatch", err)
+	}
+
+	if err := nsClient.Put(nil, key, aerospike.BinMap{"bin1": 42}); !errors.Is(err, ErrNamespaceMismatch) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNamespaceMismatch", err)
+	}
+
+	if _, err := nsClient.Delete(nil, key); !errors.Is(err, ErrNamespaceMismatch) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNamespaceMismatch", err)
+	}
+
+	if _, err := nsClient.Operate(nil, key, aerospike.GetOp()); !errors.Is(err, ErrNamespaceMismatch) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNamespaceMismatch", err)
+	}
+
+	statement := aerospike.NewStatement("aero-namespace-002", "users")
+	if _, err := nsClient.Query(nil, statement); !errors.Is(err, ErrNamespaceMismatch) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNamespaceMismatch", err)
+	}
+}
+
+func TestNamespaceClientBindStatement(t *testing.T) {
+	nsClient := NewNamespaceClient(&aerospike.Client{}, "aero-namespace-001", "users")
+
+	statement := aerospike.NewStatement("", "")
+	bound, err := nsClient.bindStatement(statement)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if bound.Namespace != "aero-namespace-001" || bound.SetName != "users" {
+		t.Errorf("got: %v/%v, want: aero-namespace-001/users", bound.Namespace, bound.SetName)
+	}
+
+	if statement.Namespace != "" || statement.SetName != "" {
+		t.Errorf("got: %v/%v, want: statement of caller unchanged", statement.Namespace, statement.SetName)
+	}
+}
+
+func TestNamespaceClientQueryNilStatement(t *testing.T) {
+	nsClient := NewNamespaceClient(&aerospike.Client{}, "aero-namespace-001", "")
+
+	if _, err := nsClient.Query(nil, nil); !errors.Is(err, ErrNilStatement) || !err.Matches(types.PARAMETER_ERROR) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrNilStatement", err)
+	}
+}
+
+func TestBuildNamespaceClientWithoutNamespace(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "")
+
+	if _, err := factory.BuildNamespaceClient(); !errors.Is(err, ErrEmptyNamespace) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrEmptyNamespace", err)
+	}
+}
@@ -0,0 +1,140 @@
+package aerofactory
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Checks run right after client is connected (See: [AerospikeClientFactory.SetPreflight]),
+// so a client that connected to the wrong cluster or with insufficient privileges is never handed out.
+type Preflight struct {
+	// Verifies factory namespace exists on every cluster node (`verify_namespace=true`)
+	VerifyNamespace bool `json:"verify_namespace,omitempty"`
+
+	// Verifies client user is granted every role (`require_roles=read-write,sindex-admin`)
+	RequireRoles []string `json:"require_roles,omitempty"`
+}
+
+// Sets preflight checks run by [AerospikeClientFactory.BuildClient] after connecting.
+// Nil disables preflight checks.
+func (cf *AerospikeClientFactory) SetPreflight(preflight *Preflight) {
+	cf.preflight = preflight
+}
+
+// Returns preflight checks or nil, if they were not set.
+func (cf *AerospikeClientFactory) GetPreflight() *Preflight {
+	return cf.preflight
+}
+
+// Runs preflight checks against connected client.
+// Returns [PreflightError] describing everything missing, or error of info & admin requests.
+func (cf *AerospikeClientFactory) runPreflight(client *aerospike.Client) aerospike.Error {
+	if cf.preflight == nil {
+		return nil
+	}
+
+	preflightErr := &PreflightError{}
+
+	if cf.preflight.VerifyNamespace {
+		if cf.namespace == "" {
+			return newFactoryError(types.PARAMETER_ERROR, ErrEmptyNamespace)
+		}
+
+		nodesNamespaces := map[string][]string{}
+		for _, node := range client.GetNodes() {
+			namespaces, err := requestNamespaces(node)
+			if err != nil {
+				return err
+			}
+
+			nodesNamespaces[node.GetName()] = namespaces
+		}
+
+		preflightErr.Namespace = cf.namespace
+		preflightErr.NodesMissingNamespace = nodesMissingNamespace(cf.namespace, nodesNamespaces)
+	}
+
+	if len(cf.preflight.RequireRoles) > 0 {
+		user := ""
+		if cf.policy != nil {
+			user = cf.policy.User
+		}
+
+		granted := []string{}
+		if user != "" {
+			userRoles, err := client.QueryUser(nil, user)
+			if err != nil {
+				return err
+			}
+
+			granted = userRoles.Roles
+		}
+
+		preflightErr.User = user
+		preflightErr.MissingRoles = missingRoles(cf.preflight.RequireRoles, granted)
+	}
+
+	if len(preflightErr.NodesMissingNamespace) == 0 && len(preflightErr.MissingRoles) == 0 {
+		return nil
+	}
+
+	return preflightErr.withResultCode()
+}
+
+// Info requests sent to a cluster node, implemented by [aerospike.Node].
+type infoRequester interface {
+	RequestInfo(policy *aerospike.InfoPolicy, name ...string) (map[string]string, aerospike.Error)
+}
+
+// Returns namespaces configured on node.
+// Passes default info policy, since [aerospike.Node.RequestInfo] dereferences it.
+func requestNamespaces(node infoRequester) ([]string, aerospike.Error) {
+	info, err := node.RequestInfo(aerospike.NewInfoPolicy(), "namespaces")
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(info["namespaces"], ";"), nil
+}
+
+// Returns sorted names of nodes namespace is missing on.
+func nodesMissingNamespace(namespace string, nodesNamespaces map[string][]string) []string {
+	missing := []string{}
+
+	for node, namespaces := range nodesNamespaces {
+		found := false
+		for _, nodeNamespace := range namespaces {
+			if nodeNamespace == namespace {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, node)
+		}
+	}
+
+	sort.Strings(missing)
+	return missing
+}
+
+// Returns required roles not present among granted ones.
+func missingRoles(required []string, granted []string) []string {
+	grantedSet := map[string]bool{}
+	for _, role := range granted {
+		grantedSet[role] = true
+	}
+
+	missing := []string{}
+	for _, role := range required {
+		if !grantedSet[role] {
+			missing = append(missing, role)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,107 @@
+package aerofactory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+func TestNodesMissingNamespace(t *testing.T) {
+	nodesNamespaces := map[string][]string{
+		"BB9020011AC4202": {"test", "aero-namespace-001"},
+		"BB9030011AC4202": {"test"},
+		"BB9010011AC4202": {""},
+	}
+
+	got := nodesMissingNamespace("aero-namespace-001", nodesNamespaces)
+	want := []string{"BB9010011AC4202", "BB9030011AC4202"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+type infoRequesterMock struct {
+	namespaces string
+}
+
+func (mock *infoRequesterMock) RequestInfo(policy *aerospike.InfoPolicy, name ...string) (map[string]string, aerospike.Error) {
+	// Mirrors aerospike.Node.RequestInfo, which reads policy.Timeout
+	_ = policy.Timeout
+
+	return map[string]string{"namespaces": mock.namespaces}, nil
+}
+
+func TestRequestNamespaces(t *testing.T) {
+	got, err := requestNamespaces(&infoRequesterMock{namespaces: "test;aero-namespace-001"})
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if !reflect.DeepEqual(got, []string{"test", "aero-namespace-001"}) {
+		t.Errorf("got: %v, want: [test aero-namespace-001]", got)
+	}
+}
+
+func TestMissingRoles(t *testing.T) {
+	got := missingRoles([]string{"read-write", "sindex-admin"}, []string{"read", "read-write"})
+
+	if !reflect.DeepEqual(got, []string{"sindex-admin"}) {
+		t.Errorf("got: %v, want: [sindex-admin]", got)
+	}
+}
+
+func TestPreflightError(t *testing.T) {
+	preflightErr := (&PreflightError{
+		Namespace:             "aero-namespace-001",
+		NodesMissingNamespace: []string{"BB9010011AC4202"},
+		User:                  "aero-user-001",
+		MissingRoles:          []string{"read-write"},
+	}).withResultCode()
+
+	want := "aerospike preflight checks failed: namespace aero-namespace-001 is missing on nodes BB9010011AC4202; user aero-user-001 is missing roles read-write"
+	if preflightErr.Error() != want {
+		t.Errorf("got: %v, want: %v", preflightErr.Error(), want)
+	}
+
+	if !errors.Is(preflightErr, ErrPreflight) || !preflightErr.Matches(types.INVALID_NAMESPACE) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrPreflight & matches INVALID_NAMESPACE", preflightErr)
+	}
+}
+
+func TestRunPreflightWithoutUser(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+	factory.SetPreflight(&Preflight{RequireRoles: []string{"read"}})
+
+	err := factory.runPreflight(&aerospike.Client{})
+
+	var preflightErr *PreflightError
+	if !errors.As(err, &preflightErr) || !reflect.DeepEqual(preflightErr.MissingRoles, []string{"read"}) {
+		t.Fatalf("got: %v, want: *aerofactory.PreflightError with missing role read", err)
+	}
+
+	if !err.Matches(types.ROLE_VIOLATION) {
+		t.Errorf("got: %v, want: error matches ROLE_VIOLATION", err)
+	}
+}
+
+func TestRunPreflightWithoutNamespace(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetPreflight(&Preflight{VerifyNamespace: true})
+
+	if err := factory.runPreflight(&aerospike.Client{}); !errors.Is(err, ErrEmptyNamespace) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrEmptyNamespace", err)
+	}
+}
+
+func TestRunPreflightDisabled(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	if err := factory.runPreflight(&aerospike.Client{}); err != nil {
+		t.Errorf("got: %v, want: error = nil", err)
+	}
+}
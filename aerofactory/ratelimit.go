@@ -0,0 +1,480 @@
+package aerofactory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Class of record operations sharing a rate limit, declared with prefix of `rate_limit` & `rate_burst`
+// URL query parameters, e.g. `write.rate_limit`.
+type OperationClass string
+
+const (
+	// Get, GetHeader & Exists
+	OperationRead OperationClass = "read"
+
+	// Put, PutBins, Add, Append, Prepend, Delete, Touch & Operate
+	OperationWrite OperationClass = "write"
+
+	// BatchGet, BatchGetHeader & BatchExists. A batch call takes a single token, whatever number of keys it has
+	OperationBatch OperationClass = "batch"
+)
+
+// Returns supported operation classes.
+func OperationClasses() []OperationClass {
+	return []OperationClass{OperationRead, OperationWrite, OperationBatch}
+}
+
+// Behaviour of [RateLimitClient] once rate limit is reached, declared with `rate_limit_mode` URL query parameter.
+type RateLimitMode string
+
+const (
+	// Wait for a token, as long as operation timeout & context (See: [RateLimitClient.WithContext]) allow
+	RateLimitWait RateLimitMode = "wait"
+
+	// Fail with [RateLimitError] at once
+	RateLimitFailFast RateLimitMode = "fail_fast"
+)
+
+// Token bucket rate limit.
+type RateLimit struct {
+	// Operations per second, e.g. 5000 for `rate_limit=5000/s`
+	Rate float64 `json:"rate"`
+
+	// Operations let through at once after an idle period (`rate_burst`). Rate rounded up, if 0
+	Burst int `json:"burst,omitempty"`
+}
+
+// Serves as rate limiting settings of [RateLimitClient], declared with `rate_limit`, `rate_burst` & `rate_limit_mode`
+// URL query parameters. Limits of operation classes are declared with class prefix, e.g. `write.rate_limit=1000/s`.
+type RateLimits struct {
+	// Limit of all operations, nil if only operation classes are limited
+	All *RateLimit `json:"all,omitempty"`
+
+	// Limits of operation classes, applied on top of All
+	Classes map[OperationClass]*RateLimit `json:"classes,omitempty"`
+
+	// Behaviour once limit is reached, [RateLimitWait] if empty
+	Mode RateLimitMode `json:"mode,omitempty"`
+}
+
+// Returns error, if any rate is not a positive finite number (e.g. NaN), any burst is negative or mode is unknown.
+// Zero burst & empty mode are valid & mean defaults.
+func (rateLimits *RateLimits) Validate() error {
+	if err := rateLimits.All.validate(); err != nil {
+		return err
+	}
+
+	for class, rateLimit := range rateLimits.Classes {
+		if err := rateLimit.validate(); err != nil {
+			return fmt.Errorf("%s: %w", class, err)
+		}
+	}
+
+	switch rateLimits.Mode {
+	case "", RateLimitWait, RateLimitFailFast:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRateLimitMode, rateLimits.Mode)
+	}
+}
+
+// Returns error, if rate is not a positive finite number or burst is negative. Nil rate limit is valid.
+func (rateLimit *RateLimit) validate() error {
+	if rateLimit == nil {
+		return nil
+	}
+
+	if !(rateLimit.Rate > 0) || math.IsInf(rateLimit.Rate, 0) {
+		return fmt.Errorf("%w: %v/s", ErrInvalidRateLimit, rateLimit.Rate)
+	}
+
+	if rateLimit.Burst < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidRateBurst, rateLimit.Burst)
+	}
+
+	return nil
+}
+
+// Returns deep copy of rate limits.
+func (rateLimits *RateLimits) clone() *RateLimits {
+	cloned := &RateLimits{Mode: rateLimits.Mode}
+
+	if rateLimits.All != nil {
+		all := *rateLimits.All
+		cloned.All = &all
+	}
+
+	if rateLimits.Classes != nil {
+		cloned.Classes = make(map[OperationClass]*RateLimit, len(rateLimits.Classes))
+		for class, rateLimit := range rateLimits.Classes {
+			if rateLimit != nil {
+				classRateLimit := *rateLimit
+				cloned.Classes[class] = &classRateLimit
+			}
+		}
+	}
+
+	return cloned
+}
+
+// Sets rate limiting settings (See: [RateLimits]). Client built by [AerospikeClientFactory.Build] is wrapped into [RateLimitClient].
+func (cf *AerospikeClientFactory) SetRateLimits(rateLimits *RateLimits) {
+	cf.rateLimits = rateLimits
+}
+
+// Returns rate limiting settings or nil, if they were not set.
+func (cf *AerospikeClientFactory) GetRateLimits() *RateLimits {
+	return cf.rateLimits
+}
+
+// Wraps client into [RateLimitClient], if rate limits were set.
+func (cf *AerospikeClientFactory) wrapRateLimit(client Client) Client {
+	if cf.rateLimits == nil {
+		return client
+	}
+
+	cf.log(slog.LevelDebug, "aerospike rate limiting enabled", "mode", cf.rateLimits.Mode)
+	return NewRateLimitClient(client, cf.rateLimits)
+}
+
+// Serves as token bucket refilled at rate up to burst tokens.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// Creates full token bucket of rate limit.
+func newTokenBucket(rateLimit *RateLimit) *tokenBucket {
+	burst := float64(rateLimit.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(rateLimit.Rate))
+	}
+
+	return &tokenBucket{rate: rateLimit.Rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// Takes a token & returns time to wait until it is available.
+// Token is not taken & false is reported, if wait would exceed maxWait.
+func (bucket *tokenBucket) reserve(now time.Time, maxWait time.Duration) (time.Duration, bool) {
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = math.Min(bucket.burst, bucket.tokens+elapsed.Seconds()*bucket.rate)
+		bucket.last = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0, true
+	}
+
+	wait := time.Duration((1 - bucket.tokens) / bucket.rate * float64(time.Second))
+	if wait > maxWait {
+		return wait, false
+	}
+
+	bucket.tokens--
+	return wait, true
+}
+
+// Returns token taken by [tokenBucket.reserve], so it can be taken by another call.
+func (bucket *tokenBucket) cancel() {
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+1)
+}
+
+// Serves as [Client] wrapper limiting rate of record operations with token buckets (See: [RateLimits]).
+// Over-limit operations wait for a token or fail with [RateLimitError], depending on [RateLimits.Mode].
+// Waiting is bounded by operation policy TotalTimeout (See: [RateLimitClient.defaultTotalTimeout] for nil policies)
+// & by context of [RateLimitClient.WithContext]. Time spent waiting is not subtracted from TotalTimeout of the operation,
+// so a call may take up to twice its TotalTimeout.
+type RateLimitClient struct {
+	Client
+
+	all     *tokenBucket
+	classes map[OperationClass]*tokenBucket
+	mode    RateLimitMode
+	ctx     context.Context
+}
+
+// Wraps [Client] into [RateLimitClient] with new token buckets of rate limits.
+func NewRateLimitClient(client Client, rateLimits *RateLimits) *RateLimitClient {
+	rateLimitClient := &RateLimitClient{
+		Client:  client,
+		classes: map[OperationClass]*tokenBucket{},
+		mode:    rateLimits.Mode,
+		ctx:     context.Background(),
+	}
+
+	if rateLimitClient.mode == "" {
+		rateLimitClient.mode = RateLimitWait
+	}
+
+	if rateLimits.All != nil {
+		rateLimitClient.all = newTokenBucket(rateLimits.All)
+	}
+
+	for class, rateLimit := range rateLimits.Classes {
+		if rateLimit != nil {
+			rateLimitClient.classes[class] = newTokenBucket(rateLimit)
+		}
+	}
+
+	return rateLimitClient
+}
+
+// Returns client sharing rate limits, which stops waiting for a token once ctx is done.
+func (rateLimitClient *RateLimitClient) WithContext(ctx context.Context) *RateLimitClient {
+	clientWithContext := *rateLimitClient
+	clientWithContext.ctx = ctx
+
+	return &clientWithContext
+}
+
+// Takes tokens of operation class & of all operations, waiting for them in [RateLimitWait] mode.
+// Returns [RateLimitError], if tokens are not available in time.
+func (rateLimitClient *RateLimitClient) take(class OperationClass, policy *aerospike.BasePolicy) aerospike.Error {
+	buckets := []*tokenBucket{}
+	if bucket := rateLimitClient.classes[class]; bucket != nil {
+		buckets = append(buckets, bucket)
+	}
+
+	if rateLimitClient.all != nil {
+		buckets = append(buckets, rateLimitClient.all)
+	}
+
+	now := time.Now()
+
+	maxWait := time.Duration(0)
+	if rateLimitClient.mode == RateLimitWait {
+		totalTimeout := rateLimitClient.defaultTotalTimeout(class)
+		if policy != nil {
+			totalTimeout = policy.TotalTimeout
+		}
+
+		// Zero TotalTimeout means operation never times out, so neither does waiting
+		maxWait = time.Duration(math.MaxInt64)
+		if totalTimeout > 0 {
+			maxWait = totalTimeout
+		}
+
+		if deadline, ok := rateLimitClient.ctx.Deadline(); ok && deadline.Sub(now) < maxWait {
+			maxWait = deadline.Sub(now)
+		}
+	}
+
+	wait := time.Duration(0)
+	for i, bucket := range buckets {
+		bucketWait, ok := bucket.reserve(now, maxWait)
+		if !ok {
+			for _, reserved := range buckets[:i] {
+				reserved.cancel()
+			}
+
+			return newRateLimitError(class, bucketWait, nil)
+		}
+
+		if bucketWait > wait {
+			wait = bucketWait
+		}
+	}
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-rateLimitClient.ctx.Done():
+		for _, bucket := range buckets {
+			bucket.cancel()
+		}
+
+		return newRateLimitError(class, 0, rateLimitClient.ctx.Err())
+	}
+}
+
+// Returns TotalTimeout of default policy wrapped client runs operations of class with, if they are called with nil policy.
+// Defaults of new policies are used, unless wrapped client is [aerospike.Client].
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (rateLimitClient *RateLimitClient) defaultTotalTimeout(class OperationClass) time.Duration {
+	client, _ := rateLimitClient.Client.(*aerospike.Client)
+
+	switch {
+	case class == OperationWrite && client != nil && client.DefaultWritePolicy != nil:
+		return client.DefaultWritePolicy.TotalTimeout
+	case class == OperationWrite:
+		return aerospike.NewWritePolicy(0, 0).TotalTimeout
+	case class == OperationBatch && client != nil && client.DefaultBatchPolicy != nil:
+		return client.DefaultBatchPolicy.TotalTimeout
+	case class == OperationBatch:
+		return aerospike.NewBatchPolicy().TotalTimeout
+	case client != nil && client.DefaultPolicy != nil:
+		return client.DefaultPolicy.TotalTimeout
+	default:
+		return aerospike.NewPolicy().TotalTimeout
+	}
+}
+
+// Returns base policy of write policy or nil, if write policy is nil.
+func writeBasePolicy(policy *aerospike.WritePolicy) *aerospike.BasePolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &policy.BasePolicy
+}
+
+// Returns base policy of batch policy or nil, if policy is nil.
+func batchBasePolicy(policy *aerospike.BatchPolicy) *aerospike.BasePolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &policy.BasePolicy
+}
+
+// Calls [Client.Get] within [OperationRead] rate limit.
+func (rateLimitClient *RateLimitClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	if err := rateLimitClient.take(OperationRead, policy); err != nil {
+		return nil, err
+	}
+
+	return rateLimitClient.Client.Get(policy, key, binNames...)
+}
+
+// Calls [Client.GetHeader] within [OperationRead] rate limit.
+func (rateLimitClient *RateLimitClient) GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+	if err := rateLimitClient.take(OperationRead, policy); err != nil {
+		return nil, err
+	}
+
+	return rateLimitClient.Client.GetHeader(policy, key)
+}
+
+// Calls [Client.Exists] within [OperationRead] rate limit.
+func (rateLimitClient *RateLimitClient) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	if err := rateLimitClient.take(OperationRead, policy); err != nil {
+		return false, err
+	}
+
+	return rateLimitClient.Client.Exists(policy, key)
+}
+
+// Calls [Client.Put] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return err
+	}
+
+	return rateLimitClient.Client.Put(policy, key, binMap)
+}
+
+// Calls [Client.PutBins] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return err
+	}
+
+	return rateLimitClient.Client.PutBins(policy, key, bins...)
+}
+
+// Calls [Client.Add] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Add(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return err
+	}
+
+	return rateLimitClient.Client.Add(policy, key, binMap)
+}
+
+// Calls [Client.Append] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Append(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return err
+	}
+
+	return rateLimitClient.Client.Append(policy, key, binMap)
+}
+
+// Calls [Client.Prepend] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Prepend(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return err
+	}
+
+	return rateLimitClient.Client.Prepend(policy, key, binMap)
+}
+
+// Calls [Client.Delete] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return false, err
+	}
+
+	return rateLimitClient.Client.Delete(policy, key)
+}
+
+// Calls [Client.Touch] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Touch(policy *aerospike.WritePolicy, key *aerospike.Key) aerospike.Error {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return err
+	}
+
+	return rateLimitClient.Client.Touch(policy, key)
+}
+
+// Calls [Client.Operate] within [OperationWrite] rate limit.
+func (rateLimitClient *RateLimitClient) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error) {
+	if err := rateLimitClient.take(OperationWrite, writeBasePolicy(policy)); err != nil {
+		return nil, err
+	}
+
+	return rateLimitClient.Client.Operate(policy, key, operations...)
+}
+
+// Calls [Client.BatchGet] within [OperationBatch] rate limit.
+func (rateLimitClient *RateLimitClient) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) ([]*aerospike.Record, aerospike.Error) {
+	if err := rateLimitClient.take(OperationBatch, batchBasePolicy(policy)); err != nil {
+		return nil, err
+	}
+
+	return rateLimitClient.Client.BatchGet(policy, keys, binNames...)
+}
+
+// Calls [Client.BatchGetHeader] within [OperationBatch] rate limit.
+func (rateLimitClient *RateLimitClient) BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]*aerospike.Record, aerospike.Error) {
+	if err := rateLimitClient.take(OperationBatch, batchBasePolicy(policy)); err != nil {
+		return nil, err
+	}
+
+	return rateLimitClient.Client.BatchGetHeader(policy, keys)
+}
+
+// Calls [Client.BatchExists] within [OperationBatch] rate limit.
+func (rateLimitClient *RateLimitClient) BatchExists(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]bool, aerospike.Error) {
+	if err := rateLimitClient.take(OperationBatch, batchBasePolicy(policy)); err != nil {
+		return nil, err
+	}
+
+	return rateLimitClient.Client.BatchExists(policy, keys)
+}
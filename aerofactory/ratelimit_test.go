@@ -0,0 +1,180 @@
+package aerofactory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+var _ Client = (*RateLimitClient)(nil)
+
+func TestRateLimitClientFailFast(t *testing.T) {
+	stub := &stubClient{}
+	client := NewRateLimitClient(stub, &RateLimits{All: &RateLimit{Rate: 1, Burst: 2}, Mode: RateLimitFailFast})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(nil, nil); err != nil {
+			t.Fatalf("got: %v, want: burst let through", err)
+		}
+	}
+
+	_, err := client.Get(nil, nil)
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || !errors.Is(err, ErrRateLimited) || !err.Matches(types.QUOTA_EXCEEDED) {
+		t.Fatalf("got: %v, want: *aerofactory.RateLimitError", err)
+	}
+
+	if rateLimitErr.Class != OperationRead || rateLimitErr.RetryAfter <= 0 || rateLimitErr.RetryAfter > time.Second {
+		t.Errorf("got: %v, %v, want: read rejected for up to 1s", rateLimitErr.Class, rateLimitErr.RetryAfter)
+	}
+
+	if stub.calls != 2 {
+		t.Errorf("got: %v calls, want: rejected call skips wrapped client", stub.calls)
+	}
+}
+
+func TestRateLimitClientClasses(t *testing.T) {
+	client := NewRateLimitClient(&stubClient{}, &RateLimits{
+		Classes: map[OperationClass]*RateLimit{OperationWrite: {Rate: 1}},
+		Mode:    RateLimitFailFast,
+	})
+
+	if err := client.Put(nil, nil, nil); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if err := client.Put(nil, nil, nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrRateLimited", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Get(nil, nil); err != nil {
+			t.Errorf("got: %v, want: reads not limited", err)
+		}
+	}
+}
+
+func TestRateLimitClientClassWithinAll(t *testing.T) {
+	client := NewRateLimitClient(&stubClient{}, &RateLimits{
+		All:     &RateLimit{Rate: 1, Burst: 2},
+		Classes: map[OperationClass]*RateLimit{OperationWrite: {Rate: 1, Burst: 5}},
+		Mode:    RateLimitFailFast,
+	})
+
+	client.Put(nil, nil, nil)
+	client.Put(nil, nil, nil)
+
+	if _, err := client.Get(nil, nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got: %v, want: writes used up limit of all operations", err)
+	}
+}
+
+func TestRateLimitClientWait(t *testing.T) {
+	client := NewRateLimitClient(&stubClient{}, &RateLimits{All: &RateLimit{Rate: 20, Burst: 1}})
+
+	started := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(nil, nil); err != nil {
+			t.Fatalf("got: %v, want: error = nil", err)
+		}
+	}
+
+	if elapsed := time.Since(started); elapsed < 90*time.Millisecond {
+		t.Errorf("got: %v, want: at least 100ms for 3 calls at 20/s", elapsed)
+	}
+}
+
+func TestRateLimitClientWaitTimeout(t *testing.T) {
+	client := NewRateLimitClient(&stubClient{}, &RateLimits{All: &RateLimit{Rate: 1, Burst: 1}})
+	client.Get(nil, nil)
+
+	policy := aerospike.NewPolicy()
+	policy.TotalTimeout = 10 * time.Millisecond
+
+	if _, err := client.Get(policy, nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got: %v, want: token not available within policy timeout", err)
+	}
+}
+
+func TestRateLimitClientWaitDefaultTimeout(t *testing.T) {
+	client := NewRateLimitClient(&stubClient{}, &RateLimits{All: &RateLimit{Rate: 0.1, Burst: 1}})
+	client.Get(nil, nil)
+
+	// Token is 10s away, beyond TotalTimeout of default policy
+	started := time.Now()
+	if _, err := client.Get(nil, nil); !errors.Is(err, ErrRateLimited) || time.Since(started) > 500*time.Millisecond {
+		t.Errorf("got: %v, want: immediate rejection beyond default policy timeout", err)
+	}
+
+	aeroClient := &aerospike.Client{DefaultPolicy: aerospike.NewPolicy()}
+	aeroClient.DefaultPolicy.TotalTimeout = time.Minute
+
+	client = NewRateLimitClient(aeroClient, &RateLimits{})
+	if timeout := client.defaultTotalTimeout(OperationRead); timeout != time.Minute {
+		t.Errorf("got: %v, want: %v of wrapped client default policy", timeout, time.Minute)
+	}
+}
+
+func TestRateLimitClientWithContext(t *testing.T) {
+	client := NewRateLimitClient(&stubClient{}, &RateLimits{All: &RateLimit{Rate: 1, Burst: 1}})
+	client.Get(nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err := client.WithContext(ctx).Get(nil, nil)
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, context.Canceled) {
+		t.Errorf("got: %v, want: wait cancelled with context", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if _, err := client.WithContext(ctx).Get(nil, nil); !errors.Is(err, ErrRateLimited) || time.Since(started) > 500*time.Millisecond {
+		t.Errorf("got: %v, want: immediate rejection past context deadline", err)
+	}
+}
+
+func TestBuildClientWarnsRateLimitsIgnored(t *testing.T) {
+	var buf bytes.Buffer
+
+	factory := unreachableFactory()
+	factory.SetRateLimits(&RateLimits{All: &RateLimit{Rate: 1}})
+	factory.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	factory.BuildClient()
+
+	if !strings.Contains(buf.String(), "level=WARN msg=\"aerospike rate limits are not applied") {
+		t.Errorf("got: %v, want: warning about ignored rate limits", buf.String())
+	}
+}
+
+func TestBuildRateLimit(t *testing.T) {
+	stub := &stubClient{}
+	stubInMemoryBuilder(t, func(cf *AerospikeClientFactory) (Client, aerospike.Error) { return stub, nil })
+
+	factory := &AerospikeClientFactory{}
+	factory.SetInMemory(true)
+	factory.SetBreaker(&Breaker{})
+	factory.SetRateLimits(&RateLimits{All: &RateLimit{Rate: 1000}})
+
+	client, _ := factory.Build()
+
+	rateLimitClient, ok := client.(*RateLimitClient)
+	if !ok {
+		t.Fatalf("got: %T, want: *aerofactory.RateLimitClient", client)
+	}
+
+	if _, ok := rateLimitClient.Client.(*BreakerClient); !ok {
+		t.Errorf("got: %T, want: *aerofactory.BreakerClient wrapped", rateLimitClient.Client)
+	}
+}
@@ -0,0 +1,544 @@
+package aerofactory
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Client mode declared with `mode` URL query parameter.
+type Mode string
+
+const (
+	// Default mode, client is allowed to read & write.
+	ModeReadWrite Mode = "readwrite"
+
+	// Client is only allowed to read (See: [ReadOnlyClient]).
+	ModeReadOnly Mode = "readonly"
+)
+
+// Serves as [aerospike.Client] wrapper refusing write operations with [ReadOnlyError] before they reach the server:
+// record writes & deletes, [aerospike.Client.Operate] with write operations, [aerospike.Client.Truncate],
+// UDF registration & execution, secondary index management, XDR filters and user & role administration.
+// Only reads, scans, queries & user & role lookups are forwarded to the wrapped client, which is not exposed.
+// Info commands sent directly to nodes returned by [ReadOnlyClient.GetNodes] are not checked.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+// [aerospike.Client.Truncate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Truncate
+type ReadOnlyClient struct {
+	client *aerospike.Client
+}
+
+// Wraps [aerospike.Client] into [ReadOnlyClient].
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func NewReadOnlyClient(client *aerospike.Client) *ReadOnlyClient {
+	return &ReadOnlyClient{client}
+}
+
+// Builds client (See: [AerospikeClientFactory.BuildClient]) wrapped into [ReadOnlyClient] regardless of client mode.
+func (cf *AerospikeClientFactory) BuildReadOnlyClient() (*ReadOnlyClient, aerospike.Error) {
+	client, err := cf.buildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReadOnlyClient(client), nil
+}
+
+// Forwards [aerospike.Client.IsConnected].
+//
+// [aerospike.Client.IsConnected]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.IsConnected
+func (roClient *ReadOnlyClient) IsConnected() bool {
+	return roClient.client.IsConnected()
+}
+
+// Forwards [aerospike.Client.Close].
+//
+// [aerospike.Client.Close]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Close
+func (roClient *ReadOnlyClient) Close() {
+	roClient.client.Close()
+}
+
+// Forwards [aerospike.Client.GetNodes].
+//
+// [aerospike.Client.GetNodes]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.GetNodes
+func (roClient *ReadOnlyClient) GetNodes() []*aerospike.Node {
+	return roClient.client.GetNodes()
+}
+
+// Forwards [aerospike.Client.GetNodeNames].
+//
+// [aerospike.Client.GetNodeNames]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.GetNodeNames
+func (roClient *ReadOnlyClient) GetNodeNames() []string {
+	return roClient.client.GetNodeNames()
+}
+
+// Forwards [aerospike.Client.String].
+//
+// [aerospike.Client.String]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.String
+func (roClient *ReadOnlyClient) String() string {
+	return roClient.client.String()
+}
+
+// Forwards [aerospike.Client.Stats].
+//
+// [aerospike.Client.Stats]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Stats
+func (roClient *ReadOnlyClient) Stats() (map[string]interface{}, aerospike.Error) {
+	return roClient.client.Stats()
+}
+
+// Forwards [aerospike.Client.WarmUp].
+//
+// [aerospike.Client.WarmUp]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.WarmUp
+func (roClient *ReadOnlyClient) WarmUp(count int) (int, aerospike.Error) {
+	return roClient.client.WarmUp(count)
+}
+
+// Forwards [aerospike.Client.Get].
+//
+// [aerospike.Client.Get]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Get
+func (roClient *ReadOnlyClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	return roClient.client.Get(policy, key, binNames...)
+}
+
+// Forwards [aerospike.Client.GetHeader].
+//
+// [aerospike.Client.GetHeader]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.GetHeader
+func (roClient *ReadOnlyClient) GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+	return roClient.client.GetHeader(policy, key)
+}
+
+// Forwards [aerospike.Client.GetObject].
+//
+// [aerospike.Client.GetObject]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.GetObject
+func (roClient *ReadOnlyClient) GetObject(policy *aerospike.BasePolicy, key *aerospike.Key, obj interface{}) aerospike.Error {
+	return roClient.client.GetObject(policy, key, obj)
+}
+
+// Forwards [aerospike.Client.Exists].
+//
+// [aerospike.Client.Exists]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Exists
+func (roClient *ReadOnlyClient) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	return roClient.client.Exists(policy, key)
+}
+
+// Forwards [aerospike.Client.BatchExists].
+//
+// [aerospike.Client.BatchExists]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchExists
+func (roClient *ReadOnlyClient) BatchExists(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]bool, aerospike.Error) {
+	return roClient.client.BatchExists(policy, keys)
+}
+
+// Forwards [aerospike.Client.BatchGet].
+//
+// [aerospike.Client.BatchGet]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGet
+func (roClient *ReadOnlyClient) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) ([]*aerospike.Record, aerospike.Error) {
+	return roClient.client.BatchGet(policy, keys, binNames...)
+}
+
+// Forwards [aerospike.Client.BatchGetHeader].
+//
+// [aerospike.Client.BatchGetHeader]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGetHeader
+func (roClient *ReadOnlyClient) BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]*aerospike.Record, aerospike.Error) {
+	return roClient.client.BatchGetHeader(policy, keys)
+}
+
+// Forwards [aerospike.Client.BatchGetObjects].
+//
+// [aerospike.Client.BatchGetObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGetObjects
+func (roClient *ReadOnlyClient) BatchGetObjects(policy *aerospike.BatchPolicy, keys []*aerospike.Key, objects []interface{}) ([]bool, aerospike.Error) {
+	return roClient.client.BatchGetObjects(policy, keys, objects)
+}
+
+// Forwards [aerospike.Client.ScanAll].
+//
+// [aerospike.Client.ScanAll]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ScanAll
+func (roClient *ReadOnlyClient) ScanAll(policy *aerospike.ScanPolicy, namespace string, setName string, binNames ...string) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.ScanAll(policy, namespace, setName, binNames...)
+}
+
+// Forwards [aerospike.Client.ScanNode].
+//
+// [aerospike.Client.ScanNode]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ScanNode
+func (roClient *ReadOnlyClient) ScanNode(policy *aerospike.ScanPolicy, node *aerospike.Node, namespace string, setName string, binNames ...string) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.ScanNode(policy, node, namespace, setName, binNames...)
+}
+
+// Forwards [aerospike.Client.ScanPartitions].
+//
+// [aerospike.Client.ScanPartitions]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ScanPartitions
+func (roClient *ReadOnlyClient) ScanPartitions(policy *aerospike.ScanPolicy, partitionFilter *aerospike.PartitionFilter, namespace string, setName string, binNames ...string) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.ScanPartitions(policy, partitionFilter, namespace, setName, binNames...)
+}
+
+// Forwards [aerospike.Client.ScanAllObjects].
+//
+// [aerospike.Client.ScanAllObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ScanAllObjects
+func (roClient *ReadOnlyClient) ScanAllObjects(policy *aerospike.ScanPolicy, objChan interface{}, namespace string, setName string, binNames ...string) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.ScanAllObjects(policy, objChan, namespace, setName, binNames...)
+}
+
+// Forwards [aerospike.Client.ScanNodeObjects].
+//
+// [aerospike.Client.ScanNodeObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ScanNodeObjects
+func (roClient *ReadOnlyClient) ScanNodeObjects(policy *aerospike.ScanPolicy, node *aerospike.Node, objChan interface{}, namespace string, setName string, binNames ...string) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.ScanNodeObjects(policy, node, objChan, namespace, setName, binNames...)
+}
+
+// Forwards [aerospike.Client.ScanPartitionObjects].
+//
+// [aerospike.Client.ScanPartitionObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ScanPartitionObjects
+func (roClient *ReadOnlyClient) ScanPartitionObjects(policy *aerospike.ScanPolicy, objChan interface{}, partitionFilter *aerospike.PartitionFilter, namespace string, setName string, binNames ...string) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.ScanPartitionObjects(policy, objChan, partitionFilter, namespace, setName, binNames...)
+}
+
+// Forwards [aerospike.Client.Query].
+//
+// [aerospike.Client.Query]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Query
+func (roClient *ReadOnlyClient) Query(policy *aerospike.QueryPolicy, statement *aerospike.Statement) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.Query(policy, statement)
+}
+
+// Forwards [aerospike.Client.QueryNode].
+//
+// [aerospike.Client.QueryNode]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryNode
+func (roClient *ReadOnlyClient) QueryNode(policy *aerospike.QueryPolicy, node *aerospike.Node, statement *aerospike.Statement) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.QueryNode(policy, node, statement)
+}
+
+// Forwards [aerospike.Client.QueryPartitions].
+//
+// [aerospike.Client.QueryPartitions]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryPartitions
+func (roClient *ReadOnlyClient) QueryPartitions(policy *aerospike.QueryPolicy, statement *aerospike.Statement, partitionFilter *aerospike.PartitionFilter) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.QueryPartitions(policy, statement, partitionFilter)
+}
+
+// Forwards [aerospike.Client.QueryObjects].
+//
+// [aerospike.Client.QueryObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryObjects
+func (roClient *ReadOnlyClient) QueryObjects(policy *aerospike.QueryPolicy, statement *aerospike.Statement, objChan interface{}) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.QueryObjects(policy, statement, objChan)
+}
+
+// Forwards [aerospike.Client.QueryNodeObjects].
+//
+// [aerospike.Client.QueryNodeObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryNodeObjects
+func (roClient *ReadOnlyClient) QueryNodeObjects(policy *aerospike.QueryPolicy, node *aerospike.Node, statement *aerospike.Statement, objChan interface{}) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.QueryNodeObjects(policy, node, statement, objChan)
+}
+
+// Forwards [aerospike.Client.QueryPartitionObjects].
+//
+// [aerospike.Client.QueryPartitionObjects]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryPartitionObjects
+func (roClient *ReadOnlyClient) QueryPartitionObjects(policy *aerospike.QueryPolicy, statement *aerospike.Statement, objChan interface{}, partitionFilter *aerospike.PartitionFilter) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.QueryPartitionObjects(policy, statement, objChan, partitionFilter)
+}
+
+// Forwards [aerospike.Client.QueryAggregate]. Stream UDFs cannot write records.
+//
+// [aerospike.Client.QueryAggregate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryAggregate
+func (roClient *ReadOnlyClient) QueryAggregate(policy *aerospike.QueryPolicy, statement *aerospike.Statement, packageName, functionName string, functionArgs ...aerospike.Value) (*aerospike.Recordset, aerospike.Error) {
+	return roClient.client.QueryAggregate(policy, statement, packageName, functionName, functionArgs...)
+}
+
+// Forwards [aerospike.Client.ListUDF].
+//
+// [aerospike.Client.ListUDF]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.ListUDF
+func (roClient *ReadOnlyClient) ListUDF(policy *aerospike.BasePolicy) ([]*aerospike.UDF, aerospike.Error) {
+	return roClient.client.ListUDF(policy)
+}
+
+// Forwards [aerospike.Client.QueryUser].
+//
+// [aerospike.Client.QueryUser]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryUser
+func (roClient *ReadOnlyClient) QueryUser(policy *aerospike.AdminPolicy, user string) (*aerospike.UserRoles, aerospike.Error) {
+	return roClient.client.QueryUser(policy, user)
+}
+
+// Forwards [aerospike.Client.QueryUsers].
+//
+// [aerospike.Client.QueryUsers]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryUsers
+func (roClient *ReadOnlyClient) QueryUsers(policy *aerospike.AdminPolicy) ([]*aerospike.UserRoles, aerospike.Error) {
+	return roClient.client.QueryUsers(policy)
+}
+
+// Forwards [aerospike.Client.QueryRole].
+//
+// [aerospike.Client.QueryRole]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryRole
+func (roClient *ReadOnlyClient) QueryRole(policy *aerospike.AdminPolicy, role string) (*aerospike.Role, aerospike.Error) {
+	return roClient.client.QueryRole(policy, role)
+}
+
+// Forwards [aerospike.Client.QueryRoles].
+//
+// [aerospike.Client.QueryRoles]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryRoles
+func (roClient *ReadOnlyClient) QueryRoles(policy *aerospike.AdminPolicy) ([]*aerospike.Role, aerospike.Error) {
+	return roClient.client.QueryRoles(policy)
+}
+
+// Performs read operations on record (See: [aerospike.Client.Operate]).
+// Refused with [ReadOnlyError], if any operation is a write.
+//
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+func (roClient *ReadOnlyClient) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error) {
+	if hasWriteOperation(operations) {
+		return nil, newReadOnlyError("Operate")
+	}
+
+	return roClient.client.Operate(policy, key, operations...)
+}
+
+// Performs read operations on records (See: [aerospike.Client.BatchGetOperate]).
+// Refused with [ReadOnlyError], if any operation is a write.
+//
+// [aerospike.Client.BatchGetOperate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGetOperate
+func (roClient *ReadOnlyClient) BatchGetOperate(policy *aerospike.BatchPolicy, keys []*aerospike.Key, operations ...*aerospike.Operation) ([]*aerospike.Record, aerospike.Error) {
+	if hasWriteOperation(operations) {
+		return nil, newReadOnlyError("BatchGetOperate")
+	}
+
+	return roClient.client.BatchGetOperate(policy, keys, operations...)
+}
+
+// Performs batch reads (See: [aerospike.Client.BatchOperate]).
+// Refused with [ReadOnlyError], if any record is not [aerospike.BatchRead] or has write operations.
+//
+// [aerospike.Client.BatchOperate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchOperate
+// [aerospike.BatchRead]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#BatchRead
+func (roClient *ReadOnlyClient) BatchOperate(policy *aerospike.BatchPolicy, records []aerospike.BatchRecordIfc) aerospike.Error {
+	for _, record := range records {
+		batchRead, ok := record.(*aerospike.BatchRead)
+		if !ok || hasWriteOperation(batchRead.Ops) {
+			return newReadOnlyError("BatchOperate")
+		}
+	}
+
+	return roClient.client.BatchOperate(policy, records)
+}
+
+// Performs batch reads (See: [aerospike.Client.BatchGetComplex]).
+// Refused with [ReadOnlyError], if any record has write operations.
+//
+// [aerospike.Client.BatchGetComplex]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGetComplex
+func (roClient *ReadOnlyClient) BatchGetComplex(policy *aerospike.BatchPolicy, records []*aerospike.BatchRead) aerospike.Error {
+	for _, record := range records {
+		if record != nil && hasWriteOperation(record.Ops) {
+			return newReadOnlyError("BatchGetComplex")
+		}
+	}
+
+	return roClient.client.BatchGetComplex(policy, records)
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return newReadOnlyError("Put")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	return newReadOnlyError("PutBins")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) PutObject(policy *aerospike.WritePolicy, key *aerospike.Key, obj interface{}) aerospike.Error {
+	return newReadOnlyError("PutObject")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Append(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return newReadOnlyError("Append")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) AppendBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	return newReadOnlyError("AppendBins")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Prepend(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return newReadOnlyError("Prepend")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) PrependBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	return newReadOnlyError("PrependBins")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Add(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return newReadOnlyError("Add")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) AddBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	return newReadOnlyError("AddBins")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	return false, newReadOnlyError("Delete")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Touch(policy *aerospike.WritePolicy, key *aerospike.Key) aerospike.Error {
+	return newReadOnlyError("Touch")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) BatchDelete(policy *aerospike.BatchPolicy, deletePolicy *aerospike.BatchDeletePolicy, keys []*aerospike.Key) ([]*aerospike.BatchRecord, aerospike.Error) {
+	return nil, newReadOnlyError("BatchDelete")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) BatchExecute(policy *aerospike.BatchPolicy, udfPolicy *aerospike.BatchUDFPolicy, keys []*aerospike.Key, packageName string, functionName string, args ...aerospike.Value) ([]*aerospike.BatchRecord, aerospike.Error) {
+	return nil, newReadOnlyError("BatchExecute")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) Truncate(policy *aerospike.WritePolicy, namespace, set string, beforeLastUpdate *time.Time) aerospike.Error {
+	return newReadOnlyError("Truncate")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) RegisterUDFFromFile(policy *aerospike.WritePolicy, clientPath string, serverPath string, language aerospike.Language) (*aerospike.RegisterTask, aerospike.Error) {
+	return nil, newReadOnlyError("RegisterUDFFromFile")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) RegisterUDF(policy *aerospike.WritePolicy, udfBody []byte, serverPath string, language aerospike.Language) (*aerospike.RegisterTask, aerospike.Error) {
+	return nil, newReadOnlyError("RegisterUDF")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) RemoveUDF(policy *aerospike.WritePolicy, udfName string) (*aerospike.RemoveTask, aerospike.Error) {
+	return nil, newReadOnlyError("RemoveUDF")
+}
+
+// Refused with [ReadOnlyError], as UDF may write.
+func (roClient *ReadOnlyClient) Execute(policy *aerospike.WritePolicy, key *aerospike.Key, packageName string, functionName string, args ...aerospike.Value) (interface{}, aerospike.Error) {
+	return nil, newReadOnlyError("Execute")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) QueryExecute(policy *aerospike.QueryPolicy, writePolicy *aerospike.WritePolicy, statement *aerospike.Statement, ops ...*aerospike.Operation) (*aerospike.ExecuteTask, aerospike.Error) {
+	return nil, newReadOnlyError("QueryExecute")
+}
+
+// Refused with [ReadOnlyError], as UDF may write.
+func (roClient *ReadOnlyClient) ExecuteUDF(policy *aerospike.QueryPolicy, statement *aerospike.Statement, packageName string, functionName string, functionArgs ...aerospike.Value) (*aerospike.ExecuteTask, aerospike.Error) {
+	return nil, newReadOnlyError("ExecuteUDF")
+}
+
+// Refused with [ReadOnlyError], as UDF may write.
+func (roClient *ReadOnlyClient) ExecuteUDFNode(policy *aerospike.QueryPolicy, node *aerospike.Node, statement *aerospike.Statement, packageName string, functionName string, functionArgs ...aerospike.Value) (*aerospike.ExecuteTask, aerospike.Error) {
+	return nil, newReadOnlyError("ExecuteUDFNode")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) CreateIndex(policy *aerospike.WritePolicy, namespace string, setName string, indexName string, binName string, indexType aerospike.IndexType) (*aerospike.IndexTask, aerospike.Error) {
+	return nil, newReadOnlyError("CreateIndex")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) CreateComplexIndex(policy *aerospike.WritePolicy, namespace string, setName string, indexName string, binName string, indexType aerospike.IndexType, indexCollectionType aerospike.IndexCollectionType, ctx ...*aerospike.CDTContext) (*aerospike.IndexTask, aerospike.Error) {
+	return nil, newReadOnlyError("CreateComplexIndex")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) DropIndex(policy *aerospike.WritePolicy, namespace string, setName string, indexName string) aerospike.Error {
+	return newReadOnlyError("DropIndex")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) SetXDRFilter(policy *aerospike.InfoPolicy, datacenter string, namespace string, filter *aerospike.Expression) aerospike.Error {
+	return newReadOnlyError("SetXDRFilter")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) CreateUser(policy *aerospike.AdminPolicy, user string, password string, roles []string) aerospike.Error {
+	return newReadOnlyError("CreateUser")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) DropUser(policy *aerospike.AdminPolicy, user string) aerospike.Error {
+	return newReadOnlyError("DropUser")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) ChangePassword(policy *aerospike.AdminPolicy, user string, password string) aerospike.Error {
+	return newReadOnlyError("ChangePassword")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) GrantRoles(policy *aerospike.AdminPolicy, user string, roles []string) aerospike.Error {
+	return newReadOnlyError("GrantRoles")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) RevokeRoles(policy *aerospike.AdminPolicy, user string, roles []string) aerospike.Error {
+	return newReadOnlyError("RevokeRoles")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) CreateRole(policy *aerospike.AdminPolicy, roleName string, privileges []aerospike.Privilege, whitelist []string, readQuota, writeQuota uint32) aerospike.Error {
+	return newReadOnlyError("CreateRole")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) DropRole(policy *aerospike.AdminPolicy, roleName string) aerospike.Error {
+	return newReadOnlyError("DropRole")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) GrantPrivileges(policy *aerospike.AdminPolicy, roleName string, privileges []aerospike.Privilege) aerospike.Error {
+	return newReadOnlyError("GrantPrivileges")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) RevokePrivileges(policy *aerospike.AdminPolicy, roleName string, privileges []aerospike.Privilege) aerospike.Error {
+	return newReadOnlyError("RevokePrivileges")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) SetWhitelist(policy *aerospike.AdminPolicy, roleName string, whitelist []string) aerospike.Error {
+	return newReadOnlyError("SetWhitelist")
+}
+
+// Refused with [ReadOnlyError].
+func (roClient *ReadOnlyClient) SetQuotas(policy *aerospike.AdminPolicy, roleName string, readQuota, writeQuota uint32) aerospike.Error {
+	return newReadOnlyError("SetQuotas")
+}
+
+// Reports whether any operation is a write (See: [isWriteOperation]).
+func hasWriteOperation(operations []*aerospike.Operation) bool {
+	for _, operation := range operations {
+		if operation != nil && isWriteOperation(reflect.ValueOf(operation).Elem()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports whether [aerospike.Operation] value is a write.
+// [aerospike.Operation] does not expose its type, so the unexported write flag is read with reflection.
+// If the flag cannot be found (e.g. Aerospike client renamed it), operation is treated as a write.
+//
+// [aerospike.Operation]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Operation
+func isWriteOperation(operation reflect.Value) bool {
+	opType := operation.FieldByName("opType")
+	if !opType.IsValid() || opType.Kind() != reflect.Struct {
+		return true
+	}
+
+	isWrite := opType.FieldByName("isWrite")
+	if !isWrite.IsValid() || isWrite.Kind() != reflect.Bool {
+		return true
+	}
+
+	return isWrite.Bool()
+}
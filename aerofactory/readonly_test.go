@@ -0,0 +1,103 @@
+package aerofactory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+func TestReadOnlyClientRefusesWrites(t *testing.T) {
+	roClient := NewReadOnlyClient(&aerospike.Client{})
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	refused := map[string]aerospike.Error{
+		"Put":          roClient.Put(nil, key, aerospike.BinMap{"bin1": 42}),
+		"PutBins":      roClient.PutBins(nil, key, aerospike.NewBin("bin1", 42)),
+		"Add":          roClient.Add(nil, key, aerospike.BinMap{"bin1": 1}),
+		"Touch":        roClient.Touch(nil, key),
+		"Truncate":     roClient.Truncate(nil, "aero-namespace-001", "users", nil),
+		"DropIndex":    roClient.DropIndex(nil, "aero-namespace-001", "users", "idx"),
+		"PutObject":    roClient.PutObject(nil, key, &struct{ Bin1 int }{42}),
+		"CreateUser":   roClient.CreateUser(nil, "aero-user-002", "aerouserpassw123", []string{"read-write"}),
+		"GrantRoles":   roClient.GrantRoles(nil, "aero-user-002", []string{"sys-admin"}),
+		"SetXDRFilter": roClient.SetXDRFilter(nil, "dc2", "aero-namespace-001", nil),
+		"BatchOperate": roClient.BatchOperate(nil, []aerospike.BatchRecordIfc{
+			aerospike.NewBatchWrite(nil, key, aerospike.PutOp(aerospike.NewBin("bin1", 42))),
+		}),
+	}
+
+	_, refused["Delete"] = roClient.Delete(nil, key)
+	_, refused["Operate"] = roClient.Operate(nil, key, aerospike.GetOp(), aerospike.AddOp(aerospike.NewBin("bin1", 1)))
+	_, refused["Execute"] = roClient.Execute(nil, key, "pkg", "fn")
+	_, refused["CreateIndex"] = roClient.CreateIndex(nil, "aero-namespace-001", "users", "idx", "bin1", aerospike.STRING)
+	_, refused["RegisterUDF"] = roClient.RegisterUDF(nil, []byte{}, "pkg.lua", aerospike.LUA)
+
+	for operation, err := range refused {
+		var readOnlyErr *ReadOnlyError
+		if !errors.As(err, &readOnlyErr) || readOnlyErr.Operation != operation {
+			t.Errorf("got: %v, want: *aerofactory.ReadOnlyError for %s", err, operation)
+			continue
+		}
+
+		if !errors.Is(err, ErrReadOnly) || !err.Matches(types.FAIL_FORBIDDEN) {
+			t.Errorf("got: %v, want: error is aerofactory.ErrReadOnly & matches FAIL_FORBIDDEN", err)
+		}
+	}
+}
+
+func TestHasWriteOperation(t *testing.T) {
+	reads := []*aerospike.Operation{aerospike.GetOp(), aerospike.GetBinOp("bin1"), aerospike.GetHeaderOp()}
+	if hasWriteOperation(reads) {
+		t.Errorf("got: true, want: read operations are not writes")
+	}
+
+	writes := append(reads, aerospike.PutOp(aerospike.NewBin("bin1", 42)))
+	if !hasWriteOperation(writes) {
+		t.Errorf("got: false, want: put operation is a write")
+	}
+
+}
+
+func TestIsWriteOperationFailsClosed(t *testing.T) {
+	unknown := map[string]interface{}{
+		"missing opType":  struct{ kind byte }{},
+		"missing isWrite": struct{ opType struct{ op byte } }{},
+		"renamed isWrite": struct{ opType struct{ isWrite int } }{},
+	}
+
+	for name, operation := range unknown {
+		if !isWriteOperation(reflect.ValueOf(operation)) {
+			t.Errorf("got: false, want: operation with %s is treated as a write", name)
+		}
+	}
+}
+
+func TestBuildClientInReadOnlyMode(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 1, "aero-namespace-001")
+	factory.SetMode(ModeReadOnly)
+
+	var readOnlyErr *ReadOnlyError
+	if _, err := factory.BuildClient(); !errors.As(err, &readOnlyErr) || readOnlyErr.Operation != "BuildClient" {
+		t.Errorf("got: %v, want: *aerofactory.ReadOnlyError for BuildClient", err)
+	}
+
+	if _, _, err := factory.BuildClientWithWarmUpReport(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	if _, err := factory.BuildReadOnlyClient(); errors.Is(err, ErrReadOnly) {
+		t.Errorf("got: %v, want: read-only client is built in read-only mode", err)
+	}
+}
+
+func TestGetModeDefault(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	if factory.GetMode() != ModeReadWrite {
+		t.Errorf("got: %v, want: %v", factory.GetMode(), ModeReadWrite)
+	}
+}
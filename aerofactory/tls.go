@@ -0,0 +1,57 @@
+package aerofactory
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+)
+
+// Serves as references to PEM files [tls.Config] of [aerospike.ClientPolicy] is built from.
+// Unlike [tls.Config], file references can be expressed in URL, JSON or tools config.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+type TLSFiles struct {
+	// CA certificate file used to verify server certificate
+	CAFile string `json:"ca_file,omitempty"`
+
+	// Client certificate file used for mutual TLS & PKI auth mode
+	CertFile string `json:"cert_file,omitempty"`
+
+	// Client certificate key file used for mutual TLS & PKI auth mode
+	KeyFile string `json:"key_file,omitempty"`
+
+	// TLS name used to verify server certificate, if not set on [aerospike.Host]
+	//
+	// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+	ServerName string `json:"server_name,omitempty"`
+}
+
+// Reads referenced PEM files & builds [tls.Config].
+// Returns error, if any of the files cannot be read or parsed.
+func (files *TLSFiles) Load() (*tls.Config, error) {
+	config := &tls.Config{ServerName: files.ServerName}
+
+	if files.CAFile != "" {
+		caPEM, err := os.ReadFile(files.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("no certificates found in tls ca file " + files.CAFile)
+		}
+	}
+
+	if files.CertFile != "" || files.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,45 @@
+package aerofactory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTLSFilesLoadServerName(t *testing.T) {
+	files := &TLSFiles{ServerName: "aero-tls-001"}
+
+	config, err := files.Load()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if config.ServerName != "aero-tls-001" {
+		t.Errorf("got: %v, want: aero-tls-001", config.ServerName)
+	}
+}
+
+func TestTLSFilesLoadMissingCAFile(t *testing.T) {
+	files := &TLSFiles{CAFile: filepath.Join(t.TempDir(), "missing.pem")}
+
+	_, err := files.Load()
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got: %v, want: error is os.ErrNotExist", err)
+	}
+}
+
+func TestBuildClientInvalidTLSFiles(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+	factory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+	factory.SetTLSFiles(&TLSFiles{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+
+	client, err := factory.BuildClient()
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got: %v, want: error is os.ErrNotExist", err)
+	}
+
+	if client != nil {
+		t.Errorf("got: %v, want: *aerospike.Client = nil", client)
+	}
+}
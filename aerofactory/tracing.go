@@ -0,0 +1,348 @@
+package aerofactory
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Serves as dependency-free tracer [TracedClient] reports operations to,
+// so it can be adapted to OpenTelemetry or any other tracing library.
+type Tracer interface {
+	// Starts span of operation. Returned span is ended once operation returns.
+	StartSpan(info SpanInfo) Span
+}
+
+// Serves as span started by [Tracer].
+type Span interface {
+	// Ends span with operation outcome.
+	End(result SpanResult)
+}
+
+// Describes traced operation.
+type SpanInfo struct {
+	// Client method, e.g. `Get` or `BatchOperate`
+	Operation string
+
+	// Namespace & set of the key or statement. Empty for batches spanning several namespaces or sets.
+	Namespace string
+	Set       string
+}
+
+// Describes outcome of traced operation.
+type SpanResult struct {
+	// Name of node that served the operation (or failed it), as reported by returned record or error.
+	// Empty, if neither reports it (e.g. for successful writes, batches & queries).
+	Node string
+
+	// Operation error or nil.
+	Err error
+}
+
+// Serves as [aerospike.Client] wrapper reporting single-record reads & writes, [aerospike.Client.Operate],
+// batches & queries to [Tracer]. Other methods pass through to the embedded client untraced.
+//
+// Queries are executed asynchronously & [aerospike.Recordset] cannot be wrapped, so only query dispatch is traced:
+// query span ends once the recordset is returned & errors streamed through it are not reported.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+// [aerospike.Recordset]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Recordset
+type TracedClient struct {
+	*aerospike.Client
+
+	tracer Tracer
+}
+
+// Wraps [aerospike.Client] into [TracedClient] reporting to tracer.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func NewTracedClient(client *aerospike.Client, tracer Tracer) *TracedClient {
+	return &TracedClient{client, tracer}
+}
+
+// Builds client (See: [AerospikeClientFactory.BuildClient]) wrapped into [TracedClient] reporting to tracer.
+// As [aerospike.Client] is wrapped, chaos, circuit breaker & rate limit settings are not applied (a warning is logged instead)
+// & [ReadOnlyError] is returned in [ModeReadOnly]. Use [AerospikeClientFactory.Build] for clients with those wrappers.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (cf *AerospikeClientFactory) BuildTracedClient(tracer Tracer) (*TracedClient, aerospike.Error) {
+	client, err := cf.BuildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTracedClient(client, tracer), nil
+}
+
+// Traced [aerospike.Client.Get].
+//
+// [aerospike.Client.Get]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Get
+func (tClient *TracedClient) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	span := tClient.tracer.StartSpan(keySpanInfo("Get", key))
+
+	record, err := tClient.Client.Get(policy, key, binNames...)
+	span.End(recordSpanResult(record, err))
+
+	return record, err
+}
+
+// Traced [aerospike.Client.GetHeader].
+//
+// [aerospike.Client.GetHeader]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.GetHeader
+func (tClient *TracedClient) GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+	span := tClient.tracer.StartSpan(keySpanInfo("GetHeader", key))
+
+	record, err := tClient.Client.GetHeader(policy, key)
+	span.End(recordSpanResult(record, err))
+
+	return record, err
+}
+
+// Traced [aerospike.Client.Put].
+//
+// [aerospike.Client.Put]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Put
+func (tClient *TracedClient) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	span := tClient.tracer.StartSpan(keySpanInfo("Put", key))
+
+	err := tClient.Client.Put(policy, key, binMap)
+	span.End(spanResult(err))
+
+	return err
+}
+
+// Traced [aerospike.Client.PutBins].
+//
+// [aerospike.Client.PutBins]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.PutBins
+func (tClient *TracedClient) PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	span := tClient.tracer.StartSpan(keySpanInfo("PutBins", key))
+
+	err := tClient.Client.PutBins(policy, key, bins...)
+	span.End(spanResult(err))
+
+	return err
+}
+
+// Traced [aerospike.Client.Operate].
+//
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+func (tClient *TracedClient) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error) {
+	span := tClient.tracer.StartSpan(keySpanInfo("Operate", key))
+
+	record, err := tClient.Client.Operate(policy, key, operations...)
+	span.End(recordSpanResult(record, err))
+
+	return record, err
+}
+
+// Traced [aerospike.Client.BatchGet].
+//
+// [aerospike.Client.BatchGet]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGet
+func (tClient *TracedClient) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) ([]*aerospike.Record, aerospike.Error) {
+	span := tClient.tracer.StartSpan(batchSpanInfo("BatchGet", keys))
+
+	records, err := tClient.Client.BatchGet(policy, keys, binNames...)
+	span.End(spanResult(err))
+
+	return records, err
+}
+
+// Traced [aerospike.Client.BatchGetHeader].
+//
+// [aerospike.Client.BatchGetHeader]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGetHeader
+func (tClient *TracedClient) BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]*aerospike.Record, aerospike.Error) {
+	span := tClient.tracer.StartSpan(batchSpanInfo("BatchGetHeader", keys))
+
+	records, err := tClient.Client.BatchGetHeader(policy, keys)
+	span.End(spanResult(err))
+
+	return records, err
+}
+
+// Traced [aerospike.Client.BatchGetOperate].
+//
+// [aerospike.Client.BatchGetOperate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGetOperate
+func (tClient *TracedClient) BatchGetOperate(policy *aerospike.BatchPolicy, keys []*aerospike.Key, operations ...*aerospike.Operation) ([]*aerospike.Record, aerospike.Error) {
+	span := tClient.tracer.StartSpan(batchSpanInfo("BatchGetOperate", keys))
+
+	records, err := tClient.Client.BatchGetOperate(policy, keys, operations...)
+	span.End(spanResult(err))
+
+	return records, err
+}
+
+// Traced [aerospike.Client.BatchOperate].
+//
+// [aerospike.Client.BatchOperate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchOperate
+func (tClient *TracedClient) BatchOperate(policy *aerospike.BatchPolicy, records []aerospike.BatchRecordIfc) aerospike.Error {
+	span := tClient.tracer.StartSpan(batchSpanInfo("BatchOperate", batchRecordKeys(records)))
+
+	err := tClient.Client.BatchOperate(policy, records)
+	span.End(spanResult(err))
+
+	return err
+}
+
+// Traced dispatch of [aerospike.Client.Query]. Span ends once recordset is returned, before results are streamed.
+//
+// [aerospike.Client.Query]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Query
+func (tClient *TracedClient) Query(policy *aerospike.QueryPolicy, statement *aerospike.Statement) (*aerospike.Recordset, aerospike.Error) {
+	span := tClient.tracer.StartSpan(SpanInfo{Operation: "Query", Namespace: statement.Namespace, Set: statement.SetName})
+
+	recordset, err := tClient.Client.Query(policy, statement)
+	span.End(spanResult(err))
+
+	return recordset, err
+}
+
+// Traced dispatch of [aerospike.Client.QueryPartitions]. Span ends once recordset is returned, before results are streamed.
+//
+// [aerospike.Client.QueryPartitions]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.QueryPartitions
+func (tClient *TracedClient) QueryPartitions(policy *aerospike.QueryPolicy, statement *aerospike.Statement, partitionFilter *aerospike.PartitionFilter) (*aerospike.Recordset, aerospike.Error) {
+	span := tClient.tracer.StartSpan(SpanInfo{Operation: "QueryPartitions", Namespace: statement.Namespace, Set: statement.SetName})
+
+	recordset, err := tClient.Client.QueryPartitions(policy, statement, partitionFilter)
+	span.End(spanResult(err))
+
+	return recordset, err
+}
+
+// Returns span result of single-record operation with node named by error or, if it succeeded, by returned record.
+func recordSpanResult(record *aerospike.Record, err aerospike.Error) SpanResult {
+	result := spanResult(err)
+	if result.Node == "" && record != nil && record.Node != nil {
+		result.Node = record.Node.GetName()
+	}
+
+	return result
+}
+
+// Returns span info of single-record operation.
+func keySpanInfo(operation string, key *aerospike.Key) SpanInfo {
+	if key == nil {
+		return SpanInfo{Operation: operation}
+	}
+
+	return SpanInfo{Operation: operation, Namespace: key.Namespace(), Set: key.SetName()}
+}
+
+// Returns keys of batch records. Nil records are skipped, so they are left to the client to reject.
+func batchRecordKeys(records []aerospike.BatchRecordIfc) []*aerospike.Key {
+	keys := make([]*aerospike.Key, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+
+		// Typed nil records, e.g. (*aerospike.BatchRead)(nil), panic on BatchRec
+		if value := reflect.ValueOf(record); value.Kind() == reflect.Ptr && value.IsNil() {
+			continue
+		}
+
+		keys = append(keys, record.BatchRec().Key)
+	}
+
+	return keys
+}
+
+// Returns span info of batch operation. Namespace & set are reported only if every key shares them.
+func batchSpanInfo(operation string, keys []*aerospike.Key) SpanInfo {
+	info := SpanInfo{Operation: operation}
+	first := true
+
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+
+		if first {
+			info.Namespace, info.Set = key.Namespace(), key.SetName()
+			first = false
+			continue
+		}
+
+		if key.Namespace() != info.Namespace {
+			info.Namespace = ""
+		}
+
+		if key.SetName() != info.Set {
+			info.Set = ""
+		}
+	}
+
+	return info
+}
+
+// Returns span result with error & node named by error, if any.
+func spanResult(err aerospike.Error) SpanResult {
+	if err == nil {
+		return SpanResult{}
+	}
+
+	result := SpanResult{Err: err}
+
+	var aeroErr *aerospike.AerospikeError
+	if errors.As(err, &aeroErr) && aeroErr.Node != nil {
+		result.Node = aeroErr.Node.GetName()
+	}
+
+	return result
+}
+
+// Serves as in-memory [Tracer] recording ended spans, e.g. for tests. Safe for concurrent use.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// Describes span recorded by [SpanRecorder].
+type RecordedSpan struct {
+	SpanInfo
+	SpanResult
+
+	Duration time.Duration
+}
+
+// Initializes empty [SpanRecorder].
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// Starts span recorded once it is ended.
+func (recorder *SpanRecorder) StartSpan(info SpanInfo) Span {
+	return &recorderSpan{recorder: recorder, info: info, started: time.Now()}
+}
+
+// Returns ended spans in the order they were ended.
+func (recorder *SpanRecorder) Spans() []RecordedSpan {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return append([]RecordedSpan(nil), recorder.spans...)
+}
+
+// Drops recorded spans.
+func (recorder *SpanRecorder) Reset() {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.spans = nil
+}
+
+// Serves as span started by [SpanRecorder].
+type recorderSpan struct {
+	recorder *SpanRecorder
+	info     SpanInfo
+	started  time.Time
+}
+
+// Records span with outcome.
+func (span *recorderSpan) End(result SpanResult) {
+	span.recorder.mu.Lock()
+	defer span.recorder.mu.Unlock()
+
+	span.recorder.spans = append(span.recorder.spans, RecordedSpan{span.info, result, time.Since(span.started)})
+}
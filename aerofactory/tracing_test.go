@@ -0,0 +1,112 @@
+package aerofactory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Returns client of unreachable Aerospike DB, so operations fail fast without connecting.
+func disconnectedClient(t *testing.T) *aerospike.Client {
+	policy := aerospike.NewClientPolicy()
+	policy.Timeout = 100 * time.Millisecond
+	policy.FailIfNotConnected = false
+
+	client, _ := aerospike.NewClientWithPolicy(policy, "127.0.0.1", 1)
+	if client == nil {
+		t.Fatal("got: client = nil, want: client != nil")
+	}
+
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestTracedClientSpans(t *testing.T) {
+	recorder := NewSpanRecorder()
+	tClient := NewTracedClient(disconnectedClient(t), recorder)
+
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+	otherKey, _ := aerospike.NewKey("aero-namespace-002", "users", "user-001")
+
+	tClient.Get(nil, key)
+	tClient.Put(nil, key, aerospike.BinMap{"name": "Alice"})
+	tClient.Operate(nil, key, aerospike.AddOp(aerospike.NewBin("visits", 1)))
+	tClient.BatchGet(nil, []*aerospike.Key{key, otherKey})
+	tClient.BatchOperate(nil, []aerospike.BatchRecordIfc{aerospike.NewBatchRead(key, nil)})
+	tClient.Query(nil, aerospike.NewStatement("aero-namespace-001", "users"))
+
+	want := []SpanInfo{
+		{Operation: "Get", Namespace: "aero-namespace-001", Set: "users"},
+		{Operation: "Put", Namespace: "aero-namespace-001", Set: "users"},
+		{Operation: "Operate", Namespace: "aero-namespace-001", Set: "users"},
+		{Operation: "BatchGet", Namespace: "", Set: "users"},
+		{Operation: "BatchOperate", Namespace: "aero-namespace-001", Set: "users"},
+		{Operation: "Query", Namespace: "aero-namespace-001", Set: "users"},
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != len(want) {
+		t.Fatalf("got: %v spans, want: %v", len(spans), len(want))
+	}
+
+	for i, span := range spans {
+		if span.SpanInfo != want[i] {
+			t.Errorf("got: %+v, want: %+v", span.SpanInfo, want[i])
+		}
+
+		if span.Err == nil {
+			t.Errorf("got: %v, want: %v failed on unreachable cluster", span.Err, span.Operation)
+		}
+	}
+}
+
+func TestTracedClientSpanRecordsResult(t *testing.T) {
+	recorder := NewSpanRecorder()
+	tClient := NewTracedClient(disconnectedClient(t), recorder)
+
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+	_, err := tClient.Get(nil, key)
+
+	spans := recorder.Spans()
+	if len(spans) != 1 || spans[0].Err != err || spans[0].Node != "" {
+		t.Errorf("got: %+v, want: span with error %v & no node", spans, err)
+	}
+
+	recorder.Reset()
+	if len(recorder.Spans()) != 0 {
+		t.Errorf("got: %v, want: no spans after reset", recorder.Spans())
+	}
+}
+
+func TestBatchSpanInfo(t *testing.T) {
+	first, _ := aerospike.NewKey("aero-namespace-001", "users", 1)
+	second, _ := aerospike.NewKey("aero-namespace-001", "users", 2)
+	otherSet, _ := aerospike.NewKey("aero-namespace-001", "orders", 3)
+
+	tests := []struct {
+		keys []*aerospike.Key
+		want SpanInfo
+	}{
+		{[]*aerospike.Key{first, second}, SpanInfo{Operation: "BatchGet", Namespace: "aero-namespace-001", Set: "users"}},
+		{[]*aerospike.Key{first, otherSet}, SpanInfo{Operation: "BatchGet", Namespace: "aero-namespace-001"}},
+		{[]*aerospike.Key{nil, first}, SpanInfo{Operation: "BatchGet", Namespace: "aero-namespace-001", Set: "users"}},
+		{nil, SpanInfo{Operation: "BatchGet"}},
+	}
+
+	for _, test := range tests {
+		if got := batchSpanInfo("BatchGet", test.keys); got != test.want {
+			t.Errorf("got: %+v, want: %+v", got, test.want)
+		}
+	}
+}
+
+func TestBatchRecordKeysSkipsNilRecords(t *testing.T) {
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", 1)
+	var typedNil *aerospike.BatchRead
+
+	keys := batchRecordKeys([]aerospike.BatchRecordIfc{nil, typedNil, aerospike.NewBatchRead(key, nil)})
+	if len(keys) != 1 || keys[0] != key {
+		t.Errorf("got: %v, want: [%v]", keys, key)
+	}
+}
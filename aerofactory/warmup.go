@@ -0,0 +1,131 @@
+package aerofactory
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Default time [AerospikeClientFactory.BuildClient] waits for connection warm-up.
+const DefaultWarmUpTimeout = 5 * time.Second
+
+// Connection warm-up run right after client is connected (See: [AerospikeClientFactory.SetWarmUp]),
+// so connection pools are filled before traffic arrives.
+type WarmUp struct {
+	// Connections per node to establish (`warm_up=<n>`)
+	Count int
+
+	// Establish `min_connections_per_node` connections per node instead of Count (`warm_up=min`)
+	Min bool
+
+	// Max time to wait for warm-up (`warm_up_timeout`), [DefaultWarmUpTimeout] if zero
+	Timeout time.Duration
+}
+
+// Reports outcome of connection warm-up.
+type WarmUpReport struct {
+	// Connections per node requested
+	Requested int
+
+	// Connections established by warm-up
+	Established int
+
+	// Connections required by `min_connections_per_node` across all nodes
+	Target int
+
+	// Established connections reached Target
+	TargetMet bool
+
+	// Warm-up did not finish within timeout & keeps running in background
+	TimedOut bool
+
+	// Time spent waiting for warm-up
+	Duration time.Duration
+
+	// Warm-up error, nil if warm-up succeeded
+	Err error
+}
+
+// Sets connection warm-up run by [AerospikeClientFactory.BuildClient] after connecting & preflight checks.
+// Nil disables warm-up.
+func (cf *AerospikeClientFactory) SetWarmUp(warmUp *WarmUp) {
+	cf.warmUp = warmUp
+}
+
+// Returns connection warm-up or nil, if it was not set.
+func (cf *AerospikeClientFactory) GetWarmUp() *WarmUp {
+	return cf.warmUp
+}
+
+// Runs connection warm-up against connected client & returns its report or nil, if warm-up was not set.
+func (cf *AerospikeClientFactory) runWarmUp(client *aerospike.Client) *WarmUpReport {
+	if cf.warmUp == nil {
+		return nil
+	}
+
+	minPerNode := 0
+	if cf.policy != nil {
+		minPerNode = cf.policy.MinConnectionsPerNode
+	}
+
+	report := warmUp(client.WarmUp, *cf.warmUp, len(client.GetNodes()), minPerNode)
+
+	level := slog.LevelInfo
+	if report.Err != nil || report.TimedOut || !report.TargetMet {
+		level = slog.LevelWarn
+	}
+
+	cf.log(level, "aerospike connection warm-up finished",
+		"requested", report.Requested, "established", report.Established, "target", report.Target,
+		"target_met", report.TargetMet, "timed_out", report.TimedOut, "duration", report.Duration, "error", report.Err)
+
+	return report
+}
+
+// Calls warm-up function with connections per node resolved from config & waits for it within timeout.
+func warmUp(warmUpFn func(count int) (int, aerospike.Error), config WarmUp, nodes int, minPerNode int) *WarmUpReport {
+	report := &WarmUpReport{Requested: config.Count, Target: minPerNode * nodes}
+
+	if config.Min {
+		report.Requested = minPerNode
+	}
+
+	// Client treats count <= 0 as "fill the whole pool", which is never what was asked for
+	if report.Requested <= 0 {
+		report.TargetMet = report.Target == 0
+		return report
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWarmUpTimeout
+	}
+
+	type result struct {
+		established int
+		err         aerospike.Error
+	}
+
+	done := make(chan result, 1)
+	started := time.Now()
+
+	go func() {
+		established, err := warmUpFn(report.Requested)
+		done <- result{established, err}
+	}()
+
+	select {
+	case res := <-done:
+		report.Established = res.established
+		if res.err != nil {
+			report.Err = res.err
+		}
+	case <-time.After(timeout):
+		report.TimedOut = true
+	}
+
+	report.Duration = time.Since(started)
+	report.TargetMet = report.Established >= report.Target
+	return report
+}
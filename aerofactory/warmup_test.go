@@ -0,0 +1,90 @@
+package aerofactory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+func TestWarmUpCount(t *testing.T) {
+	requested := 0
+	warmUpFn := func(count int) (int, aerospike.Error) {
+		requested = count
+		return count * 3, nil
+	}
+
+	report := warmUp(warmUpFn, WarmUp{Count: 8}, 3, 2)
+
+	if requested != 8 || report.Requested != 8 || report.Established != 24 {
+		t.Errorf("got: %+v, want: 8 requested & 24 established", report)
+	}
+
+	if report.Target != 6 || !report.TargetMet || report.TimedOut || report.Err != nil {
+		t.Errorf("got: %+v, want: target of 6 met", report)
+	}
+}
+
+func TestWarmUpMin(t *testing.T) {
+	warmUpFn := func(count int) (int, aerospike.Error) {
+		return 5, nil
+	}
+
+	report := warmUp(warmUpFn, WarmUp{Min: true}, 3, 2)
+
+	if report.Requested != 2 || report.Target != 6 || report.TargetMet {
+		t.Errorf("got: %+v, want: 2 requested & target of 6 not met", report)
+	}
+}
+
+func TestWarmUpMinWithoutMinConnections(t *testing.T) {
+	warmUpFn := func(count int) (int, aerospike.Error) {
+		t.Fatalf("got: warm-up with count %d, want: no warm-up", count)
+		return 0, nil
+	}
+
+	report := warmUp(warmUpFn, WarmUp{Min: true}, 3, 0)
+
+	if report.Requested != 0 || !report.TargetMet {
+		t.Errorf("got: %+v, want: nothing requested & target met", report)
+	}
+}
+
+func TestWarmUpError(t *testing.T) {
+	warmUpFn := func(count int) (int, aerospike.Error) {
+		return 1, &aerospike.AerospikeError{ResultCode: types.NETWORK_ERROR}
+	}
+
+	report := warmUp(warmUpFn, WarmUp{Count: 4}, 2, 2)
+
+	var aeroErr aerospike.Error
+	if !errors.As(report.Err, &aeroErr) || !aeroErr.Matches(types.NETWORK_ERROR) || report.TargetMet {
+		t.Errorf("got: %+v, want: network error & target not met", report)
+	}
+}
+
+func TestWarmUpTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	warmUpFn := func(count int) (int, aerospike.Error) {
+		<-release
+		return count, nil
+	}
+
+	report := warmUp(warmUpFn, WarmUp{Count: 4, Timeout: 10 * time.Millisecond}, 1, 1)
+
+	if !report.TimedOut || report.TargetMet || report.Duration < 10*time.Millisecond {
+		t.Errorf("got: %+v, want: timed out", report)
+	}
+}
+
+func TestRunWarmUpNotSet(t *testing.T) {
+	factory := &AerospikeClientFactory{}
+
+	if report := factory.runWarmUp(&aerospike.Client{}); report != nil {
+		t.Errorf("got: %v, want: nil", report)
+	}
+}
@@ -0,0 +1,542 @@
+// Aerospike URL test package.
+// In-memory test double of [aerofactory.Client] selected with `mem://` or `aerospike+mem://` URL
+// & local fake Aerospike DB [Server] for integration tests are here.
+//
+// Importing the package registers in-memory builder, so [aerofactory.AerospikeClientFactory.Build]
+// of `mem://orders/aero-namespace-001` returns [Client] of [Named] store `orders`:
+//
+//	import _ "github.com/tiptophelmet/aerospike-url/aerospikeurltest"
+package aerospikeurltest
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+func init() {
+	aerofactory.RegisterInMemoryBuilder(build)
+}
+
+// Builds [Client] of [Named] store named after factory hostname. In [aerofactory.ModeReadOnly], writes are refused.
+func build(cf *aerofactory.AerospikeClientFactory) (aerofactory.Client, aerospike.Error) {
+	client := NewClient(Named(cf.GetHostname()))
+	client.readOnly = cf.GetMode() == aerofactory.ModeReadOnly
+
+	return client, nil
+}
+
+var (
+	namedStoresMutex sync.Mutex
+	namedStores      = map[string]*Store{}
+)
+
+// Returns process-wide store registered under name, creating it on first call.
+// Clients built from `mem://<name>/...` URLs share it, so tests can seed & inspect records.
+func Named(name string) *Store {
+	namedStoresMutex.Lock()
+	defer namedStoresMutex.Unlock()
+
+	store, ok := namedStores[name]
+	if !ok {
+		store = NewStore()
+		namedStores[name] = store
+	}
+
+	return store
+}
+
+// Serves as in-memory Aerospike DB: records keyed by namespace & digest (set & user key),
+// with bins, generation & TTL. Safe for concurrent use.
+type Store struct {
+	mu         sync.Mutex
+	namespaces map[string]bool
+	records    map[recordID]*storedRecord
+	defaultTTL uint32
+	now        func() time.Time
+}
+
+// Identifies record the same way Aerospike DB does.
+type recordID struct {
+	namespace string
+	digest    [20]byte
+}
+
+// Holds stored record. Zero expiresAt means record never expires.
+type storedRecord struct {
+	bins       aerospike.BinMap
+	generation uint32
+	expiresAt  time.Time
+}
+
+// Initializes empty [Store]. If namespaces are passed, commands targeting other namespaces fail
+// with [types.INVALID_NAMESPACE], otherwise every namespace is accepted.
+//
+// [types.INVALID_NAMESPACE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#INVALID_NAMESPACE
+func NewStore(namespaces ...string) *Store {
+	store := &Store{records: map[recordID]*storedRecord{}, now: time.Now}
+
+	if len(namespaces) > 0 {
+		store.namespaces = map[string]bool{}
+		for _, namespace := range namespaces {
+			store.namespaces[namespace] = true
+		}
+	}
+
+	return store
+}
+
+// Sets clock TTLs are counted with, e.g. to expire records in tests without waiting.
+func (store *Store) SetNow(now func() time.Time) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.now = now
+}
+
+// Sets TTL in seconds of records written with [aerospike.TTLServerDefault], same as namespace `default-ttl`.
+// Zero (default) means records never expire.
+//
+// [aerospike.TTLServerDefault]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#TTLServerDefault
+func (store *Store) SetDefaultTTL(ttl uint32) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.defaultTTL = ttl
+}
+
+// Returns number of records that have not expired.
+func (store *Store) Len() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	count := 0
+	for _, record := range store.records {
+		if !store.expired(record) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Drops every record.
+func (store *Store) Reset() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.records = map[recordID]*storedRecord{}
+}
+
+// Aerospike client operation types (See: [aerospike.OperationType]) supported by in-memory client.
+//
+// [aerospike.OperationType]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#OperationType
+const (
+	opRead    byte = 1
+	opWrite   byte = 2
+	opAdd     byte = 5
+	opAppend  byte = 9
+	opPrepend byte = 10
+	opTouch   byte = 11
+	opDelete  byte = 14
+)
+
+// Holds single operation of a command.
+type operation struct {
+	opType     byte
+	binName    string
+	binValue   interface{}
+	headerOnly bool
+}
+
+// Returns operation of [aerospike.Operation]. Operation fields are unexported, so they are read with reflection.
+// Returns error, if operation type is not supported.
+//
+// [aerospike.Operation]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Operation
+func newOperation(op *aerospike.Operation) (operation, aerospike.Error) {
+	return operationOf(reflect.ValueOf(op).Elem())
+}
+
+// Returns operation of addressable [aerospike.Operation] struct value.
+// Returns [UnsupportedOperationError] instead of panicking, if any field is missing or has unexpected kind,
+// e.g. after Aerospike client renamed it.
+//
+// [aerospike.Operation]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Operation
+func operationOf(fields reflect.Value) (operation, aerospike.Error) {
+	opTypeField, ok := operationField(fields, "opType", reflect.Struct)
+	if !ok {
+		return operation{}, newUnsupportedOperationError(0)
+	}
+
+	opField, ok := operationField(opTypeField, "op", reflect.Uint8)
+	if !ok {
+		return operation{}, newUnsupportedOperationError(0)
+	}
+
+	opType := byte(opField.Uint())
+	switch opType {
+	case opRead, opWrite, opAdd, opAppend, opPrepend, opTouch, opDelete:
+	default:
+		return operation{}, newUnsupportedOperationError(opType)
+	}
+
+	opSubType, subTypeOK := operationField(fields, "opSubType", reflect.Ptr)
+	ctx, ctxOK := operationField(fields, "ctx", reflect.Slice)
+	valueField, valueOK := operationField(fields, "binValue", reflect.Interface)
+	binName, binNameOK := operationField(fields, "binName", reflect.String)
+	headerOnly, headerOnlyOK := operationField(fields, "headerOnly", reflect.Bool)
+
+	if !subTypeOK || !ctxOK || !valueOK || !binNameOK || !headerOnlyOK || !valueField.CanAddr() {
+		return operation{}, newUnsupportedOperationError(opType)
+	}
+
+	if !opSubType.IsNil() || ctx.Len() > 0 {
+		return operation{}, newUnsupportedOperationError(opType)
+	}
+
+	value := reflect.NewAt(valueField.Type(), unsafe.Pointer(valueField.UnsafeAddr())).Elem().Interface()
+
+	return operation{opType, binName.String(), normalizeValue(value), headerOnly.Bool()}, nil
+}
+
+// Returns field of struct value by name & reports whether it exists & has kind.
+func operationField(fields reflect.Value, name string, kind reflect.Kind) (reflect.Value, bool) {
+	if fields.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	field := fields.FieldByName(name)
+	return field, field.IsValid() && field.Kind() == kind
+}
+
+// Returns operations of bin map with operation type.
+func binMapOperations(opType byte, binMap aerospike.BinMap) []operation {
+	operations := make([]operation, 0, len(binMap))
+	for name, value := range binMap {
+		operations = append(operations, operation{opType: opType, binName: name, binValue: normalizeValue(value)})
+	}
+
+	return operations
+}
+
+// Executes operations of a command on record, the way Aerospike DB executes [aerospike.Client.Operate].
+// Read-only commands fail with [types.KEY_NOT_FOUND_ERROR], if record does not exist.
+// Write commands honour record exists action, generation policy & expiration of write policy.
+//
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+// [types.KEY_NOT_FOUND_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_NOT_FOUND_ERROR
+func (store *Store) execute(policy *aerospike.WritePolicy, key *aerospike.Key, operations []operation) (*aerospike.Record, aerospike.Error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	id, err := store.recordID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		policy = aerospike.NewWritePolicy(0, 0)
+	}
+
+	stored := store.lookup(id)
+
+	write := false
+	for _, op := range operations {
+		if op.opType != opRead {
+			write = true
+		}
+	}
+
+	if !write {
+		if stored == nil {
+			return nil, newError(types.KEY_NOT_FOUND_ERROR)
+		}
+
+		return store.record(key, stored, readBins(stored.bins, operations)), nil
+	}
+
+	if err := checkWrite(policy, stored, operations); err != nil {
+		return nil, err
+	}
+
+	bins := aerospike.BinMap{}
+	if stored != nil && policy.RecordExistsAction != aerospike.REPLACE && policy.RecordExistsAction != aerospike.REPLACE_ONLY {
+		for name, value := range stored.bins {
+			bins[name] = value
+		}
+	}
+
+	result := aerospike.BinMap{}
+	for _, op := range operations {
+		if err := applyOperation(bins, result, op); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(bins) == 0 {
+		delete(store.records, id)
+		return &aerospike.Record{Key: key, Bins: result}, nil
+	}
+
+	updated := &storedRecord{bins: bins, generation: 1, expiresAt: store.expiresAt(policy.Expiration, stored)}
+	if stored != nil {
+		updated.generation = stored.generation + 1
+	}
+
+	store.records[id] = updated
+	return store.record(key, updated, result), nil
+}
+
+// Deletes record. Reports whether record existed.
+func (store *Store) delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	id, err := store.recordID(key)
+	if err != nil {
+		return false, err
+	}
+
+	stored := store.lookup(id)
+	if stored == nil {
+		return false, nil
+	}
+
+	if policy != nil {
+		if err := checkGeneration(policy, stored); err != nil {
+			return false, err
+		}
+	}
+
+	delete(store.records, id)
+	return true, nil
+}
+
+// Returns stored record or nil, if it does not exist. Expired record is dropped.
+func (store *Store) lookup(id recordID) *storedRecord {
+	stored, ok := store.records[id]
+	if !ok {
+		return nil
+	}
+
+	if store.expired(stored) {
+		delete(store.records, id)
+		return nil
+	}
+
+	return stored
+}
+
+// Returns identity of key. Returns error, if key is nil or its namespace is not accepted.
+func (store *Store) recordID(key *aerospike.Key) (recordID, aerospike.Error) {
+	if key == nil {
+		return recordID{}, newError(types.PARAMETER_ERROR)
+	}
+
+	if store.namespaces != nil && !store.namespaces[key.Namespace()] {
+		return recordID{}, newError(types.INVALID_NAMESPACE)
+	}
+
+	id := recordID{namespace: key.Namespace()}
+	copy(id.digest[:], key.Digest())
+
+	return id, nil
+}
+
+// Reports whether record has expired.
+func (store *Store) expired(stored *storedRecord) bool {
+	return !stored.expiresAt.IsZero() && !store.now().Before(stored.expiresAt)
+}
+
+// Returns expiry time of written record for write policy expiration.
+func (store *Store) expiresAt(expiration uint32, stored *storedRecord) time.Time {
+	switch expiration {
+	case aerospike.TTLDontUpdate:
+		if stored != nil {
+			return stored.expiresAt
+		}
+
+		return store.expiresAt(aerospike.TTLServerDefault, nil)
+	case aerospike.TTLDontExpire:
+		return time.Time{}
+	case aerospike.TTLServerDefault:
+		if store.defaultTTL == 0 {
+			return time.Time{}
+		}
+
+		return store.now().Add(time.Duration(store.defaultTTL) * time.Second)
+	default:
+		return store.now().Add(time.Duration(expiration) * time.Second)
+	}
+}
+
+// Returns [aerospike.Record] of stored record with bins.
+//
+// [aerospike.Record]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Record
+func (store *Store) record(key *aerospike.Key, stored *storedRecord, bins aerospike.BinMap) *aerospike.Record {
+	expiration := uint32(aerospike.TTLDontExpire)
+	if !stored.expiresAt.IsZero() {
+		expiration = uint32(math.Ceil(stored.expiresAt.Sub(store.now()).Seconds()))
+	}
+
+	return &aerospike.Record{Key: key, Bins: bins, Generation: stored.generation, Expiration: expiration}
+}
+
+// Checks record exists action, generation policy & operations applicable to missing record.
+func checkWrite(policy *aerospike.WritePolicy, stored *storedRecord, operations []operation) aerospike.Error {
+	if stored == nil {
+		switch policy.RecordExistsAction {
+		case aerospike.UPDATE_ONLY, aerospike.REPLACE_ONLY:
+			return newError(types.KEY_NOT_FOUND_ERROR)
+		}
+
+		for _, op := range operations {
+			if op.opType == opTouch {
+				return newError(types.KEY_NOT_FOUND_ERROR)
+			}
+		}
+
+		return nil
+	}
+
+	if policy.RecordExistsAction == aerospike.CREATE_ONLY {
+		return newError(types.KEY_EXISTS_ERROR)
+	}
+
+	return checkGeneration(policy, stored)
+}
+
+// Checks generation policy against stored record generation.
+func checkGeneration(policy *aerospike.WritePolicy, stored *storedRecord) aerospike.Error {
+	switch policy.GenerationPolicy {
+	case aerospike.EXPECT_GEN_EQUAL:
+		if policy.Generation != stored.generation {
+			return newError(types.GENERATION_ERROR)
+		}
+	case aerospike.EXPECT_GEN_GT:
+		if policy.Generation <= stored.generation {
+			return newError(types.GENERATION_ERROR)
+		}
+	}
+
+	return nil
+}
+
+// Returns bins requested by read operations.
+func readBins(bins aerospike.BinMap, operations []operation) aerospike.BinMap {
+	result := aerospike.BinMap{}
+	for _, op := range operations {
+		readBin(bins, result, op)
+	}
+
+	return result
+}
+
+// Copies bins requested by read operation into result. Header-only reads request no bins.
+func readBin(bins aerospike.BinMap, result aerospike.BinMap, op operation) {
+	switch {
+	case op.headerOnly:
+	case op.binName == "":
+		for name, value := range bins {
+			result[name] = value
+		}
+	default:
+		if value, ok := bins[op.binName]; ok {
+			result[op.binName] = value
+		}
+	}
+}
+
+// Applies operation to bins, copying read bins into result.
+// Returns [types.BIN_TYPE_ERROR], if operation does not apply to bin value type.
+//
+// [types.BIN_TYPE_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#BIN_TYPE_ERROR
+func applyOperation(bins aerospike.BinMap, result aerospike.BinMap, op operation) aerospike.Error {
+	switch op.opType {
+	case opRead:
+		readBin(bins, result, op)
+	case opWrite:
+		if op.binValue == nil {
+			delete(bins, op.binName)
+		} else {
+			bins[op.binName] = op.binValue
+		}
+	case opAdd:
+		sum, ok := add(bins[op.binName], op.binValue)
+		if !ok {
+			return newError(types.BIN_TYPE_ERROR)
+		}
+
+		bins[op.binName] = sum
+	case opAppend, opPrepend:
+		current, currentOk := bins[op.binName].(string)
+		value, valueOk := op.binValue.(string)
+		if (!currentOk && bins[op.binName] != nil) || !valueOk {
+			return newError(types.BIN_TYPE_ERROR)
+		}
+
+		if op.opType == opAppend {
+			bins[op.binName] = current + value
+		} else {
+			bins[op.binName] = value + current
+		}
+	case opDelete:
+		for name := range bins {
+			delete(bins, name)
+		}
+	}
+
+	return nil
+}
+
+// Returns sum of integer or float bin values. Missing bin is treated as zero.
+// Reports false, if values are of different or non-numeric types.
+func add(current interface{}, value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case int:
+		if current == nil {
+			return value, true
+		}
+
+		if current, ok := current.(int); ok {
+			return current + value, true
+		}
+	case float64:
+		if current == nil {
+			return value, true
+		}
+
+		if current, ok := current.(float64); ok {
+			return current + value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Returns bin value the way Aerospike client returns it after a round trip:
+// integers as int, floats as float64 & [aerospike.Value] as its object.
+//
+// [aerospike.Value]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Value
+func normalizeValue(value interface{}) interface{} {
+	if asValue, ok := value.(aerospike.Value); ok {
+		value = asValue.GetObject()
+	}
+
+	switch number := reflect.ValueOf(value); number.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(number.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(number.Uint())
+	case reflect.Float32, reflect.Float64:
+		return number.Float()
+	}
+
+	return value
+}
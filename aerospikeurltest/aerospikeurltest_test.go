@@ -0,0 +1,183 @@
+package aerospikeurltest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	aerospikeurl "github.com/tiptophelmet/aerospike-url"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+func TestBuildFromInMemoryURL(t *testing.T) {
+	t.Cleanup(Named("aerospikeurltest-build").Reset)
+
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+	Named("aerospikeurltest-build").execute(nil, key, binMapOperations(opWrite, aerospike.BinMap{"name": "Alice"}))
+
+	for _, connStr := range []string{"mem://aerospikeurltest-build/aero-namespace-001", "aerospike+mem://aerospikeurltest-build/aero-namespace-001"} {
+		clientFactory, err := aerospikeurl.Parse(connStr)
+		if err != nil {
+			t.Fatalf("got: %v, want: error = nil", err)
+		}
+
+		client, aeroErr := clientFactory.Build()
+		if aeroErr != nil {
+			t.Fatalf("got: %v, want: error = nil", aeroErr)
+		}
+
+		record, aeroErr := client.Get(nil, key)
+		if aeroErr != nil || record.Bins["name"] != "Alice" {
+			t.Errorf("got: %v, %v, want: record seeded into named store", record, aeroErr)
+		}
+	}
+}
+
+func TestBuildReadOnlyFromInMemoryURL(t *testing.T) {
+	clientFactory, err := aerospikeurl.Parse("mem://aerospikeurltest-readonly/aero-namespace-001?mode=readonly")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	client, aeroErr := clientFactory.Build()
+	if aeroErr != nil {
+		t.Fatalf("got: %v, want: error = nil", aeroErr)
+	}
+
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	var readOnlyErr *aerofactory.ReadOnlyError
+	if err := client.Put(nil, key, aerospike.BinMap{"name": "Alice"}); !errors.As(err, &readOnlyErr) || readOnlyErr.Operation != "Put" {
+		t.Errorf("got: %v, want: *aerofactory.ReadOnlyError for Put", err)
+	}
+
+	if _, err := client.Get(nil, key); err == nil || !err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		t.Errorf("got: %v, want: KEY_NOT_FOUND_ERROR", err)
+	}
+}
+
+func TestNamedReturnsSameStore(t *testing.T) {
+	if Named("aerospikeurltest-named") != Named("aerospikeurltest-named") {
+		t.Error("got: different stores, want: same store for the same name")
+	}
+
+	if Named("aerospikeurltest-named") == Named("aerospikeurltest-other") {
+		t.Error("got: same store, want: different stores for different names")
+	}
+}
+
+func TestStoreNamespaces(t *testing.T) {
+	client := NewClient(NewStore("aero-namespace-001"))
+	key, _ := aerospike.NewKey("aero-namespace-002", "users", "user-001")
+
+	if err := client.Put(nil, key, aerospike.BinMap{"name": "Alice"}); err == nil || !err.Matches(types.INVALID_NAMESPACE) {
+		t.Errorf("got: %v, want: INVALID_NAMESPACE", err)
+	}
+}
+
+func TestStoreTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := NewStore()
+	store.SetNow(func() time.Time { return now })
+	store.SetDefaultTTL(60)
+	client := NewClient(store)
+
+	expiring, _ := aerospike.NewKey("aero-namespace-001", "sessions", "session-001")
+	defaulted, _ := aerospike.NewKey("aero-namespace-001", "sessions", "session-002")
+	permanent, _ := aerospike.NewKey("aero-namespace-001", "sessions", "session-003")
+
+	client.Put(aerospike.NewWritePolicy(0, 10), expiring, aerospike.BinMap{"user": "user-001"})
+	client.Put(nil, defaulted, aerospike.BinMap{"user": "user-002"})
+	client.Put(aerospike.NewWritePolicy(0, aerospike.TTLDontExpire), permanent, aerospike.BinMap{"user": "user-003"})
+
+	now = now.Add(4 * time.Second)
+
+	record, _ := client.GetHeader(nil, expiring)
+	if record == nil || record.Expiration != 6 {
+		t.Fatalf("got: %v, want: expiration 6", record)
+	}
+
+	// Touch with TTLDontUpdate keeps expiration
+	client.Touch(aerospike.NewWritePolicy(0, aerospike.TTLDontUpdate), expiring)
+	if record, _ := client.GetHeader(nil, expiring); record.Expiration != 6 || record.Generation != 2 {
+		t.Errorf("got: %v, %v, want: expiration 6 & generation 2", record.Expiration, record.Generation)
+	}
+
+	now = now.Add(6 * time.Second)
+
+	if exists, _ := client.Exists(nil, expiring); exists {
+		t.Error("got: record exists, want: record expired")
+	}
+
+	if record, _ := client.GetHeader(nil, defaulted); record == nil || record.Expiration != 50 {
+		t.Errorf("got: %v, want: expiration 50 of default TTL", record)
+	}
+
+	if record, _ := client.GetHeader(nil, permanent); record == nil || record.Expiration != aerospike.TTLDontExpire {
+		t.Errorf("got: %v, want: record never expiring", record)
+	}
+
+	if store.Len() != 2 {
+		t.Errorf("got: %v, want: 2", store.Len())
+	}
+}
+
+func TestOperationUnsupported(t *testing.T) {
+	client := NewClient(NewStore())
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	_, err := client.Operate(nil, key, aerospike.ListAppendOp("tags", "new"))
+
+	var unsupportedErr *UnsupportedOperationError
+	if !errors.As(err, &unsupportedErr) || !errors.Is(err, ErrUnsupportedOperation) || !err.Matches(types.UNSUPPORTED_FEATURE) {
+		t.Errorf("got: %v, want: *aerospikeurltest.UnsupportedOperationError", err)
+	}
+}
+
+func TestOperationUnknownLayout(t *testing.T) {
+	type opType struct{ op byte }
+
+	for name, fields := range map[string]interface{}{
+		"missing opType":  &struct{ binName string }{},
+		"renamed op":      &struct{ opType struct{ code byte } }{},
+		"missing binName": &struct{ opType opType }{opType{opRead}},
+		"int headerOnly": &struct {
+			opType     opType
+			opSubType  *int
+			ctx        []interface{}
+			binValue   interface{}
+			binName    string
+			headerOnly int
+		}{opType: opType{opRead}},
+	} {
+		_, err := operationOf(reflect.ValueOf(fields).Elem())
+		if !errors.Is(err, ErrUnsupportedOperation) {
+			t.Errorf("%s: got: %v, want: error is aerospikeurltest.ErrUnsupportedOperation", name, err)
+		}
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	tests := map[interface{}]interface{}{
+		int32(7):                          7,
+		uint16(7):                         7,
+		float32(0.5):                      0.5,
+		"Alice":                           "Alice",
+		aerospike.NewIntegerValue(7):      7,
+		aerospike.NewStringValue("Alice"): "Alice",
+	}
+
+	for value, want := range tests {
+		if got := normalizeValue(value); got != want {
+			t.Errorf("got: %v (%T), want: %v (%T)", got, got, want, want)
+		}
+	}
+
+	if got := normalizeValue(aerospike.NewNullValue()); got != nil {
+		t.Errorf("got: %v, want: nil", got)
+	}
+}
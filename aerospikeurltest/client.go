@@ -0,0 +1,217 @@
+package aerospikeurltest
+
+import (
+	"sync/atomic"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Serves as in-memory [aerofactory.Client] backed by [Store]. Safe for concurrent use.
+//
+// Supports record exists actions, generation policies & TTLs of write policies, and
+// [aerospike.Client.Operate] with read, write, add, append, prepend, touch & delete operations.
+// CDT, bitwise, HyperLogLog & expression operations fail with [UnsupportedOperationError].
+//
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+type Client struct {
+	store    *Store
+	readOnly bool
+	closed   atomic.Bool
+}
+
+// Initializes [Client] backed by store.
+func NewClient(store *Store) *Client {
+	return &Client{store: store}
+}
+
+// Returns store client is backed by.
+func (client *Client) Store() *Store {
+	return client.store
+}
+
+// Reads record bins (all bins, if no bin names are passed).
+// Returns [types.KEY_NOT_FOUND_ERROR], if record does not exist.
+//
+// [types.KEY_NOT_FOUND_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_NOT_FOUND_ERROR
+func (client *Client) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (*aerospike.Record, aerospike.Error) {
+	if len(binNames) == 0 {
+		return client.execute("Get", nil, key, []operation{{opType: opRead}})
+	}
+
+	operations := make([]operation, 0, len(binNames))
+	for _, binName := range binNames {
+		operations = append(operations, operation{opType: opRead, binName: binName})
+	}
+
+	return client.execute("Get", nil, key, operations)
+}
+
+// Reads record generation & expiration only.
+// Returns [types.KEY_NOT_FOUND_ERROR], if record does not exist.
+//
+// [types.KEY_NOT_FOUND_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_NOT_FOUND_ERROR
+func (client *Client) GetHeader(policy *aerospike.BasePolicy, key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+	return client.execute("GetHeader", nil, key, []operation{{opType: opRead, headerOnly: true}})
+}
+
+// Reports whether record exists.
+func (client *Client) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	_, err := client.GetHeader(policy, key)
+	if err != nil && err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Writes bins. Bins with nil value are removed.
+func (client *Client) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	_, err := client.execute("Put", policy, key, binMapOperations(opWrite, binMap))
+	return err
+}
+
+// Writes bins. Bins with nil value are removed.
+func (client *Client) PutBins(policy *aerospike.WritePolicy, key *aerospike.Key, bins ...*aerospike.Bin) aerospike.Error {
+	operations := make([]operation, 0, len(bins))
+	for _, bin := range bins {
+		operations = append(operations, operation{opType: opWrite, binName: bin.Name, binValue: normalizeValue(bin.Value)})
+	}
+
+	_, err := client.execute("PutBins", policy, key, operations)
+	return err
+}
+
+// Adds integer or float values to bins.
+func (client *Client) Add(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	_, err := client.execute("Add", policy, key, binMapOperations(opAdd, binMap))
+	return err
+}
+
+// Appends string values to bins.
+func (client *Client) Append(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	_, err := client.execute("Append", policy, key, binMapOperations(opAppend, binMap))
+	return err
+}
+
+// Prepends string values to bins.
+func (client *Client) Prepend(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	_, err := client.execute("Prepend", policy, key, binMapOperations(opPrepend, binMap))
+	return err
+}
+
+// Deletes record. Reports whether record existed.
+func (client *Client) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (bool, aerospike.Error) {
+	if err := client.check("Delete", true); err != nil {
+		return false, err
+	}
+
+	return client.store.delete(policy, key)
+}
+
+// Resets record expiration & increments its generation.
+// Returns [types.KEY_NOT_FOUND_ERROR], if record does not exist.
+//
+// [types.KEY_NOT_FOUND_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#KEY_NOT_FOUND_ERROR
+func (client *Client) Touch(policy *aerospike.WritePolicy, key *aerospike.Key) aerospike.Error {
+	_, err := client.execute("Touch", policy, key, []operation{{opType: opTouch}})
+	return err
+}
+
+// Executes operations on record in order. Returns bins requested by read operations.
+// Returns [UnsupportedOperationError], if any operation is not supported.
+func (client *Client) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (*aerospike.Record, aerospike.Error) {
+	ops := make([]operation, 0, len(operations))
+	for _, op := range operations {
+		parsed, err := newOperation(op)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, parsed)
+	}
+
+	return client.execute("Operate", policy, key, ops)
+}
+
+// Reads records. Missing records are returned as nil.
+func (client *Client) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) ([]*aerospike.Record, aerospike.Error) {
+	return client.batch(keys, func(key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+		return client.Get(nil, key, binNames...)
+	})
+}
+
+// Reads record headers. Missing records are returned as nil.
+func (client *Client) BatchGetHeader(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]*aerospike.Record, aerospike.Error) {
+	return client.batch(keys, func(key *aerospike.Key) (*aerospike.Record, aerospike.Error) {
+		return client.GetHeader(nil, key)
+	})
+}
+
+// Reports whether records exist.
+func (client *Client) BatchExists(policy *aerospike.BatchPolicy, keys []*aerospike.Key) ([]bool, aerospike.Error) {
+	records, err := client.BatchGetHeader(policy, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make([]bool, len(records))
+	for i, record := range records {
+		exists[i] = record != nil
+	}
+
+	return exists, nil
+}
+
+// Reports whether client was not closed.
+func (client *Client) IsConnected() bool {
+	return !client.closed.Load()
+}
+
+// Closes client. Store keeps its records.
+func (client *Client) Close() {
+	client.closed.Store(true)
+}
+
+// Executes operations of client method on store, refusing writes of read-only client.
+func (client *Client) execute(method string, policy *aerospike.WritePolicy, key *aerospike.Key, operations []operation) (*aerospike.Record, aerospike.Error) {
+	write := false
+	for _, op := range operations {
+		write = write || op.opType != opRead
+	}
+
+	if err := client.check(method, write); err != nil {
+		return nil, err
+	}
+
+	return client.store.execute(policy, key, operations)
+}
+
+// Reads records one by one, returning missing records as nil.
+func (client *Client) batch(keys []*aerospike.Key, read func(key *aerospike.Key) (*aerospike.Record, aerospike.Error)) ([]*aerospike.Record, aerospike.Error) {
+	records := make([]*aerospike.Record, len(keys))
+	for i, key := range keys {
+		record, err := read(key)
+		if err != nil && !err.Matches(types.KEY_NOT_FOUND_ERROR) {
+			return nil, err
+		}
+
+		records[i] = record
+	}
+
+	return records, nil
+}
+
+// Returns error, if client was closed or is read-only & client method writes.
+func (client *Client) check(method string, write bool) aerospike.Error {
+	if client.closed.Load() {
+		return newError(types.INVALID_NODE_ERROR)
+	}
+
+	if client.readOnly && write {
+		return &aerofactory.ReadOnlyError{AerospikeError: &aerospike.AerospikeError{ResultCode: types.FAIL_FORBIDDEN}, Operation: method}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package aerospikeurltest
+
+import (
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+var _ aerofactory.Client = (*Client)(nil)
+
+func TestClientPutGetDelete(t *testing.T) {
+	client := NewClient(NewStore())
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	if err := client.Put(nil, key, aerospike.BinMap{"name": "Alice", "age": int64(30)}); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	record, err := client.Get(nil, key)
+	if err != nil || record.Bins["name"] != "Alice" || record.Bins["age"] != 30 || record.Generation != 1 {
+		t.Fatalf("got: %v, %v, want: record of generation 1", record, err)
+	}
+
+	if record, _ := client.Get(nil, key, "age"); len(record.Bins) != 1 || record.Bins["age"] != 30 {
+		t.Errorf("got: %v, want: age bin only", record.Bins)
+	}
+
+	// Nil value removes bin
+	client.PutBins(nil, key, aerospike.NewBin("age", nil))
+	if record, _ := client.Get(nil, key); len(record.Bins) != 1 || record.Generation != 2 {
+		t.Errorf("got: %v, %v, want: name bin only & generation 2", record.Bins, record.Generation)
+	}
+
+	existed, err := client.Delete(nil, key)
+	if !existed || err != nil {
+		t.Errorf("got: %v, %v, want: true, nil", existed, err)
+	}
+
+	if _, err := client.Get(nil, key); err == nil || !err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		t.Errorf("got: %v, want: KEY_NOT_FOUND_ERROR", err)
+	}
+
+	if existed, _ := client.Delete(nil, key); existed {
+		t.Errorf("got: %v, want: false", existed)
+	}
+}
+
+func TestClientSetsAreSeparate(t *testing.T) {
+	client := NewClient(NewStore())
+	users, _ := aerospike.NewKey("aero-namespace-001", "users", "id-001")
+	orders, _ := aerospike.NewKey("aero-namespace-001", "orders", "id-001")
+
+	client.Put(nil, users, aerospike.BinMap{"name": "Alice"})
+
+	if exists, _ := client.Exists(nil, orders); exists {
+		t.Error("got: record exists, want: sets do not share records")
+	}
+}
+
+func TestClientRecordExistsAction(t *testing.T) {
+	client := NewClient(NewStore())
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	policy := aerospike.NewWritePolicy(0, 0)
+	policy.RecordExistsAction = aerospike.UPDATE_ONLY
+
+	if err := client.Put(policy, key, aerospike.BinMap{"name": "Alice"}); err == nil || !err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		t.Errorf("got: %v, want: KEY_NOT_FOUND_ERROR", err)
+	}
+
+	policy.RecordExistsAction = aerospike.CREATE_ONLY
+	if err := client.Put(policy, key, aerospike.BinMap{"name": "Alice", "age": 30}); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if err := client.Put(policy, key, aerospike.BinMap{"name": "Bob"}); err == nil || !err.Matches(types.KEY_EXISTS_ERROR) {
+		t.Errorf("got: %v, want: KEY_EXISTS_ERROR", err)
+	}
+
+	policy.RecordExistsAction = aerospike.REPLACE
+	client.Put(policy, key, aerospike.BinMap{"name": "Bob"})
+
+	if record, _ := client.Get(nil, key); len(record.Bins) != 1 || record.Bins["name"] != "Bob" {
+		t.Errorf("got: %v, want: replaced bins", record.Bins)
+	}
+}
+
+func TestClientGenerationPolicy(t *testing.T) {
+	client := NewClient(NewStore())
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	client.Put(nil, key, aerospike.BinMap{"name": "Alice"})
+	client.Put(nil, key, aerospike.BinMap{"name": "Alice B."})
+
+	policy := aerospike.NewWritePolicy(1, 0)
+	policy.GenerationPolicy = aerospike.EXPECT_GEN_EQUAL
+
+	if err := client.Put(policy, key, aerospike.BinMap{"name": "Bob"}); err == nil || !err.Matches(types.GENERATION_ERROR) {
+		t.Errorf("got: %v, want: GENERATION_ERROR", err)
+	}
+
+	if _, err := client.Delete(policy, key); err == nil || !err.Matches(types.GENERATION_ERROR) {
+		t.Errorf("got: %v, want: GENERATION_ERROR", err)
+	}
+
+	policy.Generation = 2
+	if err := client.Put(policy, key, aerospike.BinMap{"name": "Bob"}); err != nil {
+		t.Errorf("got: %v, want: error = nil", err)
+	}
+
+	policy.GenerationPolicy = aerospike.EXPECT_GEN_GT
+	policy.Generation = 3
+	if err := client.Put(policy, key, aerospike.BinMap{"name": "Carol"}); err == nil || !err.Matches(types.GENERATION_ERROR) {
+		t.Errorf("got: %v, want: GENERATION_ERROR", err)
+	}
+}
+
+func TestClientAddAppendPrepend(t *testing.T) {
+	client := NewClient(NewStore())
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	client.Add(nil, key, aerospike.BinMap{"visits": 1, "score": 0.5})
+	client.Add(nil, key, aerospike.BinMap{"visits": 2, "score": 0.25})
+	client.Put(nil, key, aerospike.BinMap{"name": "lic"})
+	client.Append(nil, key, aerospike.BinMap{"name": "e"})
+	client.Prepend(nil, key, aerospike.BinMap{"name": "A"})
+
+	record, _ := client.Get(nil, key)
+	if record.Bins["visits"] != 3 || record.Bins["score"] != 0.75 || record.Bins["name"] != "Alice" {
+		t.Errorf("got: %v, want: visits 3, score 0.75 & name Alice", record.Bins)
+	}
+
+	if err := client.Add(nil, key, aerospike.BinMap{"name": 1}); err == nil || !err.Matches(types.BIN_TYPE_ERROR) {
+		t.Errorf("got: %v, want: BIN_TYPE_ERROR", err)
+	}
+
+	if err := client.Append(nil, key, aerospike.BinMap{"visits": "x"}); err == nil || !err.Matches(types.BIN_TYPE_ERROR) {
+		t.Errorf("got: %v, want: BIN_TYPE_ERROR", err)
+	}
+}
+
+func TestClientOperate(t *testing.T) {
+	client := NewClient(NewStore())
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	record, err := client.Operate(nil, key,
+		aerospike.PutOp(aerospike.NewBin("name", "Alice")),
+		aerospike.AddOp(aerospike.NewBin("visits", 1)),
+		aerospike.GetBinOp("visits"),
+	)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if len(record.Bins) != 1 || record.Bins["visits"] != 1 || record.Generation != 1 {
+		t.Errorf("got: %v, want: visits bin of generation 1", record)
+	}
+
+	record, _ = client.Operate(nil, key, aerospike.GetOp())
+	if len(record.Bins) != 2 || record.Generation != 1 {
+		t.Errorf("got: %v, want: all bins, generation unchanged by read", record)
+	}
+
+	record, _ = client.Operate(nil, key, aerospike.GetHeaderOp())
+	if len(record.Bins) != 0 {
+		t.Errorf("got: %v, want: no bins", record.Bins)
+	}
+
+	client.Operate(nil, key, aerospike.DeleteOp())
+	if exists, _ := client.Exists(nil, key); exists {
+		t.Error("got: record exists, want: record deleted")
+	}
+
+	if _, err := client.Operate(nil, key, aerospike.TouchOp()); err == nil || !err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		t.Errorf("got: %v, want: KEY_NOT_FOUND_ERROR", err)
+	}
+}
+
+func TestClientBatch(t *testing.T) {
+	client := NewClient(NewStore())
+	first, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+	missing, _ := aerospike.NewKey("aero-namespace-001", "users", "user-002")
+
+	client.Put(nil, first, aerospike.BinMap{"name": "Alice"})
+
+	records, err := client.BatchGet(nil, []*aerospike.Key{first, missing})
+	if err != nil || len(records) != 2 || records[0].Bins["name"] != "Alice" || records[1] != nil {
+		t.Errorf("got: %v, %v, want: [record nil]", records, err)
+	}
+
+	exists, err := client.BatchExists(nil, []*aerospike.Key{first, missing})
+	if err != nil || !exists[0] || exists[1] {
+		t.Errorf("got: %v, %v, want: [true false]", exists, err)
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	store := NewStore()
+	client := NewClient(store)
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+
+	client.Put(nil, key, aerospike.BinMap{"name": "Alice"})
+	client.Close()
+
+	if client.IsConnected() {
+		t.Error("got: connected, want: closed")
+	}
+
+	if _, err := client.Get(nil, key); err == nil {
+		t.Errorf("got: %v, want: error != nil", err)
+	}
+
+	if record, _ := NewClient(store).Get(nil, key); record == nil {
+		t.Error("got: nil, want: store keeps records after close")
+	}
+}
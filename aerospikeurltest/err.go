@@ -0,0 +1,50 @@
+// Aerospike URL test package.
+// In-memory test double of [aerofactory.Client] selected with `mem://` or `aerospike+mem://` URL
+// & local fake Aerospike DB [Server] for integration tests are here.
+package aerospikeurltest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Operation is not supported by in-memory client, e.g. CDT or expression operation passed to [Client.Operate]
+var ErrUnsupportedOperation = errors.New("aerospike operation is not supported by in-memory client")
+
+// Serves as [aerospike.Error] for operations in-memory client does not support.
+// Matches [ErrUnsupportedOperation] with [errors.Is] & [types.UNSUPPORTED_FEATURE] with [aerospike.Error.Matches].
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [aerospike.Error.Matches]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+// [types.UNSUPPORTED_FEATURE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#UNSUPPORTED_FEATURE
+type UnsupportedOperationError struct {
+	*aerospike.AerospikeError
+
+	// Aerospike client operation type, e.g. 3 for CDT read
+	OperationType byte
+}
+
+// Creates [UnsupportedOperationError] for operation type.
+func newUnsupportedOperationError(operationType byte) *UnsupportedOperationError {
+	return &UnsupportedOperationError{&aerospike.AerospikeError{ResultCode: types.UNSUPPORTED_FEATURE}, operationType}
+}
+
+// Returns error message with operation type.
+func (unsupportedErr *UnsupportedOperationError) Error() string {
+	return fmt.Sprintf("%s: operation type %d", ErrUnsupportedOperation, unsupportedErr.OperationType)
+}
+
+// Returns [ErrUnsupportedOperation].
+func (unsupportedErr *UnsupportedOperationError) Unwrap() error {
+	return ErrUnsupportedOperation
+}
+
+// Creates [aerospike.Error] with result code, same as Aerospike DB would fail the command with.
+//
+// [aerospike.Error]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Error
+func newError(code types.ResultCode) aerospike.Error {
+	return &aerospike.AerospikeError{ResultCode: code}
+}
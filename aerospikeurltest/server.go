@@ -0,0 +1,754 @@
+package aerospikeurltest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/pkg/bcrypt"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// Serves as local stand-in of single-node Aerospike DB cluster, so [aerospike.Client] connects to it
+// with [aerospike.NewClientWithPolicy] & factory, TLS, auth & retry behaviour can be tested offline.
+//
+// Server speaks enough of Aerospike info, admin & message protocols: node, features, partition map, peers & cluster name
+// info commands, login & session token authentication, and single record commands
+// ([aerospike.Client.Get], [aerospike.Client.Put], [aerospike.Client.Operate], etc.) executed on [Store].
+// Batch, query, scan & UDF commands fail with [types.UNSUPPORTED_FEATURE].
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+// [aerospike.NewClientWithPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#NewClientWithPolicy
+// [aerospike.Client.Get]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Get
+// [aerospike.Client.Put]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Put
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+// [types.UNSUPPORTED_FEATURE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#UNSUPPORTED_FEATURE
+type Server struct {
+	listener net.Listener
+	options  ServerOptions
+	store    *Store
+
+	mu       sync.Mutex
+	conns    map[net.Conn]bool
+	tokens   map[string]bool
+	dropNext int
+	latency  time.Duration
+	closed   bool
+
+	wg sync.WaitGroup
+}
+
+// Serves as [Server] configuration.
+type ServerOptions struct {
+	// Namespaces served, "test" is served if none are set
+	Namespaces []string
+
+	// Cluster name returned by `cluster-name` info command, checked against [aerospike.ClientPolicy.ClusterName]
+	//
+	// [aerospike.ClientPolicy.ClusterName]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+	ClusterName string
+
+	// Users & their passwords. If set, security is enabled & clients must log in
+	Users map[string]string
+
+	// TLS config. If set, server accepts TLS connections only
+	TLSConfig *tls.Config
+}
+
+// Name of the only node of the cluster, returned by `node` info command.
+const serverNodeName = "BB9000000000001"
+
+// Session TTL in seconds of login session tokens.
+const serverSessionTTL = 24 * 60 * 60
+
+// Aerospike wire protocol constants (See: Aerospike client `command.go` & `admin_command.go`).
+const (
+	protoVersion = 2
+
+	protoTypeInfo    = 1
+	protoTypeAdmin   = 2
+	protoTypeMessage = 3
+
+	protoHeaderSize   = 8
+	adminHeaderSize   = 16
+	messageHeaderSize = 22
+
+	adminAuthenticate = 0
+	adminLogin        = 20
+
+	adminFieldUser         = 0
+	adminFieldCredential   = 3
+	adminFieldSessionToken = 5
+	adminFieldSessionTTL   = 6
+
+	fieldNamespace = 0
+	fieldSet       = 1
+	fieldDigest    = 4
+
+	info1Read      = 1 << 0
+	info1Batch     = 1 << 3
+	info1NoBinData = 1 << 5
+
+	info2Delete       = 1 << 1
+	info2Generation   = 1 << 2
+	info2GenerationGT = 1 << 3
+	info2CreateOnly   = 1 << 5
+
+	info3Last            = 1 << 0
+	info3UpdateOnly      = 1 << 3
+	info3CreateOrReplace = 1 << 4
+	info3ReplaceOnly     = 1 << 5
+
+	particleNull    = 0
+	particleInteger = 1
+	particleFloat   = 2
+	particleString  = 3
+	particleBlob    = 4
+	particleBool    = 17
+
+	partitionCount = 4096
+)
+
+// Starts [Server] listening on a random loopback port. Options are optional.
+// Server must be closed with [Server.Close].
+func NewServer(options *ServerOptions) (*Server, error) {
+	server := &Server{conns: map[net.Conn]bool{}, tokens: map[string]bool{}}
+	if options != nil {
+		server.options = *options
+	}
+
+	if len(server.options.Namespaces) == 0 {
+		server.options.Namespaces = []string{"test"}
+	}
+
+	server.store = NewStore(server.options.Namespaces...)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	if server.options.TLSConfig != nil {
+		listener = tls.NewListener(listener, server.options.TLSConfig)
+	}
+
+	server.listener = listener
+
+	server.wg.Add(1)
+	go server.serve()
+
+	return server, nil
+}
+
+// Returns server hostname.
+func (server *Server) Hostname() string {
+	return server.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Returns server port.
+func (server *Server) Port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Returns server [aerospike.Host] with TLS name, e.g. to set seed host of TLS connection.
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func (server *Server) Host(tlsName string) *aerospike.Host {
+	return &aerospike.Host{Name: server.Hostname(), Port: server.Port(), TLSName: tlsName}
+}
+
+// Returns Aerospike URL of server namespace, e.g. `aerospike://127.0.0.1:41523/test`.
+func (server *Server) URL(namespace string) string {
+	return fmt.Sprintf("aerospike://%s/%s", net.JoinHostPort(server.Hostname(), strconv.Itoa(server.Port())), namespace)
+}
+
+// Returns [Store] commands are executed on, so tests can inspect & reset records.
+func (server *Server) Store() *Store {
+	return server.store
+}
+
+// Drops connection of the next count record commands without responding,
+// the way a crashing node does, e.g. to test client retries.
+func (server *Server) DropNext(count int) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	server.dropNext = count
+}
+
+// Delays responses to record commands, e.g. to test client timeouts.
+func (server *Server) SetLatency(latency time.Duration) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	server.latency = latency
+}
+
+// Stops listening & closes every open connection.
+func (server *Server) Close() error {
+	server.mu.Lock()
+	server.closed = true
+	for conn := range server.conns {
+		conn.Close()
+	}
+	server.mu.Unlock()
+
+	err := server.listener.Close()
+	server.wg.Wait()
+
+	return err
+}
+
+// Accepts connections until server is closed.
+func (server *Server) serve() {
+	defer server.wg.Done()
+
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		server.mu.Lock()
+		if server.closed {
+			server.mu.Unlock()
+			conn.Close()
+			return
+		}
+
+		server.conns[conn] = true
+		server.mu.Unlock()
+
+		server.wg.Add(1)
+		go server.serveConn(conn)
+	}
+}
+
+// Serves requests of a connection until it is closed, or a request cannot be served.
+// Connections are authenticated by login or session token, unless security is disabled.
+func (server *Server) serveConn(conn net.Conn) {
+	defer server.wg.Done()
+	defer func() {
+		server.mu.Lock()
+		delete(server.conns, conn)
+		server.mu.Unlock()
+
+		conn.Close()
+	}()
+
+	authenticated := len(server.options.Users) == 0
+
+	for {
+		protoType, body, err := readProto(conn)
+		if err != nil {
+			return
+		}
+
+		var response []byte
+		switch protoType {
+		case protoTypeInfo:
+			response = server.serveInfo(body, authenticated)
+		case protoTypeAdmin:
+			response = server.serveAdmin(body, &authenticated)
+		case protoTypeMessage:
+			if !server.beforeMessage() {
+				return
+			}
+
+			response = server.serveMessage(body, authenticated)
+		default:
+			return
+		}
+
+		if _, err := conn.Write(response); err != nil {
+			return
+		}
+	}
+}
+
+// Applies injected faults before record command is served.
+// Reports false, if connection must be dropped.
+func (server *Server) beforeMessage() bool {
+	server.mu.Lock()
+	latency := server.latency
+	drop := server.dropNext > 0
+	if drop {
+		server.dropNext--
+	}
+	server.mu.Unlock()
+
+	if drop {
+		return false
+	}
+
+	time.Sleep(latency)
+	return true
+}
+
+// Reads protocol message: 8 byte header of version, type & 48 bit size, then body of that size.
+func readProto(conn net.Conn) (byte, []byte, error) {
+	header := make([]byte, protoHeaderSize)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return 0, nil, err
+	}
+
+	if header[0] != protoVersion {
+		return 0, nil, fmt.Errorf("invalid protocol version %d", header[0])
+	}
+
+	size := binary.BigEndian.Uint64(header) & 0xFFFFFFFFFFFF
+	body := make([]byte, size)
+	if _, err := io.ReadFull(conn, body); err != nil {
+		return 0, nil, err
+	}
+
+	return header[1], body, nil
+}
+
+// Returns protocol message of type with body.
+func writeProto(protoType byte, body []byte) []byte {
+	message := make([]byte, protoHeaderSize, protoHeaderSize+len(body))
+	binary.BigEndian.PutUint64(message, uint64(protoVersion)<<56|uint64(protoType)<<48|uint64(len(body)))
+
+	return append(message, body...)
+}
+
+// Serves info request of newline separated command names with `name\tvalue\n` per name.
+// Unauthenticated connection is refused the way Aerospike DB refuses it.
+func (server *Server) serveInfo(body []byte, authenticated bool) []byte {
+	if !authenticated {
+		return writeProto(protoTypeInfo, []byte(fmt.Sprintf("ERROR:%d:not authenticated\n", types.NOT_AUTHENTICATED)))
+	}
+
+	var response strings.Builder
+	for _, name := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+		if name == "" {
+			continue
+		}
+
+		response.WriteString(name + "\t" + server.info(name) + "\n")
+	}
+
+	return writeProto(protoTypeInfo, []byte(response.String()))
+}
+
+// Returns value of info command. Unknown commands return empty value.
+func (server *Server) info(name string) string {
+	switch name {
+	case "build":
+		return "6.4.0.0"
+	case "node":
+		return serverNodeName
+	case "partition-generation", "peers-generation":
+		return "1"
+	case "features":
+		return "pscans;pquery;query-show;batch-any"
+	case "cluster-name":
+		return server.options.ClusterName
+	case "namespaces":
+		return strings.Join(server.options.Namespaces, ";")
+	case "peers-clear-std", "peers-clear-alt", "peers-tls-std", "peers-tls-alt":
+		// No peers, this node is the whole cluster
+		return fmt.Sprintf("1,%d,[]", server.Port())
+	case "service-clear-std", "service-clear-alt", "service-tls-std", "service-tls-alt":
+		return net.JoinHostPort(server.Hostname(), strconv.Itoa(server.Port()))
+	case "replicas":
+		return server.replicas()
+	}
+
+	return ""
+}
+
+// Returns partition map of every namespace: AP regime, single replica & this node owning every partition.
+func (server *Server) replicas() string {
+	bitmap := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xFF}, partitionCount/8))
+
+	var replicas strings.Builder
+	for _, namespace := range server.options.Namespaces {
+		replicas.WriteString(namespace + ":0,1," + bitmap + ";")
+	}
+
+	return replicas.String()
+}
+
+// Serves admin request. Login & session token authentication are supported, other commands fail with [types.INVALID_COMMAND].
+// If security is disabled, [types.SECURITY_NOT_ENABLED] is returned, so client carries on without login.
+//
+// [types.INVALID_COMMAND]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#INVALID_COMMAND
+// [types.SECURITY_NOT_ENABLED]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#SECURITY_NOT_ENABLED
+func (server *Server) serveAdmin(body []byte, authenticated *bool) []byte {
+	if len(body) < adminHeaderSize {
+		return adminResponse(types.PARSE_ERROR, nil)
+	}
+
+	if len(server.options.Users) == 0 {
+		return adminResponse(types.SECURITY_NOT_ENABLED, nil)
+	}
+
+	fields := readAdminFields(body[adminHeaderSize:], int(body[3]))
+
+	switch body[2] {
+	case adminLogin:
+		password, ok := server.options.Users[string(fields[adminFieldUser])]
+		if !ok {
+			return adminResponse(types.INVALID_USER, nil)
+		}
+
+		credential, err := bcrypt.Hash(password, "$2a$10$7EqJtq98hPqEX7fNZaFWoO")
+		if err != nil || credential != string(fields[adminFieldCredential]) {
+			return adminResponse(types.INVALID_CREDENTIAL, nil)
+		}
+
+		token := make([]byte, 32)
+		if _, err := rand.Read(token); err != nil {
+			return adminResponse(types.SERVER_ERROR, nil)
+		}
+
+		server.mu.Lock()
+		server.tokens[string(token)] = true
+		server.mu.Unlock()
+
+		*authenticated = true
+
+		ttl := make([]byte, 4)
+		binary.BigEndian.PutUint32(ttl, serverSessionTTL)
+
+		return adminResponse(types.OK, map[byte][]byte{adminFieldSessionToken: token, adminFieldSessionTTL: ttl})
+	case adminAuthenticate:
+		server.mu.Lock()
+		valid := server.tokens[string(fields[adminFieldSessionToken])]
+		server.mu.Unlock()
+
+		if !valid {
+			return adminResponse(types.NOT_AUTHENTICATED, nil)
+		}
+
+		*authenticated = true
+		return adminResponse(types.OK, nil)
+	}
+
+	return adminResponse(types.INVALID_COMMAND, nil)
+}
+
+// Returns admin fields by id. Each field is 4 byte size (including id), 1 byte id & data.
+func readAdminFields(data []byte, count int) map[byte][]byte {
+	fields := map[byte][]byte{}
+	for i := 0; i < count && len(data) >= 5; i++ {
+		size := int(binary.BigEndian.Uint32(data))
+		if size < 1 || 4+size > len(data) {
+			break
+		}
+
+		fields[data[4]] = data[5 : 4+size]
+		data = data[4+size:]
+	}
+
+	return fields
+}
+
+// Returns admin response with result code & fields.
+func adminResponse(code types.ResultCode, fields map[byte][]byte) []byte {
+	body := make([]byte, adminHeaderSize)
+	body[1] = byte(code)
+	body[3] = byte(len(fields))
+
+	for id, data := range fields {
+		field := make([]byte, 5, 5+len(data))
+		binary.BigEndian.PutUint32(field, uint32(len(data)+1))
+		field[4] = id
+		body = append(body, append(field, data...)...)
+	}
+
+	return writeProto(protoTypeAdmin, body)
+}
+
+// Serves record command on [Store] & returns message with result code, generation, void time & read bins.
+func (server *Server) serveMessage(body []byte, authenticated bool) []byte {
+	if !authenticated {
+		return messageResponse(types.NOT_AUTHENTICATED, nil)
+	}
+
+	command, err := readCommand(body)
+	if err != nil {
+		return messageResponse(resultCode(err), nil)
+	}
+
+	if command.delete {
+		existed, err := server.store.delete(command.policy, command.key)
+		if err != nil {
+			return messageResponse(resultCode(err), nil)
+		}
+
+		if !existed {
+			return messageResponse(types.KEY_NOT_FOUND_ERROR, nil)
+		}
+
+		return messageResponse(types.OK, nil)
+	}
+
+	record, err := server.store.execute(command.policy, command.key, command.operations)
+	if err != nil {
+		return messageResponse(resultCode(err), nil)
+	}
+
+	return messageResponse(types.OK, record)
+}
+
+// Holds record command read from message.
+type serverCommand struct {
+	policy     *aerospike.WritePolicy
+	key        *aerospike.Key
+	operations []operation
+	delete     bool
+}
+
+// Reads record command from message body: 22 byte header, fields & operations.
+// Returns [types.UNSUPPORTED_FEATURE] error for commands other than single record commands.
+//
+// [types.UNSUPPORTED_FEATURE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#UNSUPPORTED_FEATURE
+func readCommand(body []byte) (*serverCommand, aerospike.Error) {
+	if len(body) < messageHeaderSize {
+		return nil, newError(types.PARSE_ERROR)
+	}
+
+	info1, info2, info3 := body[1], body[2], body[3]
+	if info1&info1Batch != 0 {
+		return nil, newError(types.UNSUPPORTED_FEATURE)
+	}
+
+	policy := aerospike.NewWritePolicy(binary.BigEndian.Uint32(body[6:]), binary.BigEndian.Uint32(body[10:]))
+	switch {
+	case info2&info2CreateOnly != 0:
+		policy.RecordExistsAction = aerospike.CREATE_ONLY
+	case info3&info3UpdateOnly != 0:
+		policy.RecordExistsAction = aerospike.UPDATE_ONLY
+	case info3&info3CreateOrReplace != 0:
+		policy.RecordExistsAction = aerospike.REPLACE
+	case info3&info3ReplaceOnly != 0:
+		policy.RecordExistsAction = aerospike.REPLACE_ONLY
+	}
+
+	switch {
+	case info2&info2Generation != 0:
+		policy.GenerationPolicy = aerospike.EXPECT_GEN_EQUAL
+	case info2&info2GenerationGT != 0:
+		policy.GenerationPolicy = aerospike.EXPECT_GEN_GT
+	}
+
+	fieldCount := int(binary.BigEndian.Uint16(body[18:]))
+	opCount := int(binary.BigEndian.Uint16(body[20:]))
+	data := body[messageHeaderSize:]
+
+	var namespace, set string
+	var digest []byte
+	for i := 0; i < fieldCount; i++ {
+		if len(data) < 5 {
+			return nil, newError(types.PARSE_ERROR)
+		}
+
+		size := int(binary.BigEndian.Uint32(data))
+		if size < 1 || 4+size > len(data) {
+			return nil, newError(types.PARSE_ERROR)
+		}
+
+		switch value := data[5 : 4+size]; data[4] {
+		case fieldNamespace:
+			namespace = string(value)
+		case fieldSet:
+			set = string(value)
+		case fieldDigest:
+			digest = value
+		}
+
+		data = data[4+size:]
+	}
+
+	if digest == nil {
+		// Scans & queries address partitions, not records
+		return nil, newError(types.UNSUPPORTED_FEATURE)
+	}
+
+	key, err := aerospike.NewKeyWithDigest(namespace, set, nil, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	command := &serverCommand{policy: policy, key: key}
+
+	if info2&info2Delete != 0 {
+		command.delete = true
+		return command, nil
+	}
+
+	for i := 0; i < opCount; i++ {
+		op, rest, err := readOperation(data)
+		if err != nil {
+			return nil, err
+		}
+
+		command.operations = append(command.operations, op)
+		data = rest
+	}
+
+	// Get & exists commands read whole record or its header without operations
+	if opCount == 0 && info1&info1Read != 0 {
+		command.operations = []operation{{opType: opRead, headerOnly: info1&info1NoBinData != 0}}
+	}
+
+	return command, nil
+}
+
+// Reads operation: 4 byte size, operation type, particle type, version, bin name size, bin name & value.
+func readOperation(data []byte) (operation, []byte, aerospike.Error) {
+	if len(data) < 8 {
+		return operation{}, nil, newError(types.PARSE_ERROR)
+	}
+
+	size := int(binary.BigEndian.Uint32(data))
+	nameSize := int(data[7])
+	if size < 4+nameSize || 4+size > len(data) {
+		return operation{}, nil, newError(types.PARSE_ERROR)
+	}
+
+	op := operation{opType: data[4], binName: string(data[8 : 8+nameSize])}
+	switch op.opType {
+	case opRead, opWrite, opAdd, opAppend, opPrepend, opTouch, opDelete:
+	default:
+		return operation{}, nil, newUnsupportedOperationError(op.opType)
+	}
+
+	value, err := readParticle(data[5], data[8+nameSize:4+size])
+	if err != nil {
+		return operation{}, nil, err
+	}
+
+	op.binValue = value
+	return op, data[4+size:], nil
+}
+
+// Returns value of particle type, the way [Store] holds bin values.
+// Returns [types.UNSUPPORTED_FEATURE] error for CDT, GeoJSON & HLL particles.
+//
+// [types.UNSUPPORTED_FEATURE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#UNSUPPORTED_FEATURE
+func readParticle(particleType byte, data []byte) (interface{}, aerospike.Error) {
+	switch particleType {
+	case particleNull:
+		return nil, nil
+	case particleInteger, particleFloat:
+		if len(data) != 8 {
+			return nil, newError(types.PARSE_ERROR)
+		}
+
+		if particleType == particleFloat {
+			return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+		}
+
+		return int(int64(binary.BigEndian.Uint64(data))), nil
+	case particleString:
+		return string(data), nil
+	case particleBlob:
+		return append([]byte(nil), data...), nil
+	case particleBool:
+		return len(data) > 0 && data[0] != 0, nil
+	}
+
+	return nil, newError(types.UNSUPPORTED_FEATURE)
+}
+
+// Returns particle type & data of bin value. Reports false, if value type cannot be sent.
+func writeParticle(value interface{}) (byte, []byte, bool) {
+	switch value := value.(type) {
+	case int:
+		return particleInteger, binary.BigEndian.AppendUint64(nil, uint64(value)), true
+	case float64:
+		return particleFloat, binary.BigEndian.AppendUint64(nil, math.Float64bits(value)), true
+	case string:
+		return particleString, []byte(value), true
+	case []byte:
+		return particleBlob, value, true
+	case bool:
+		if value {
+			return particleBool, []byte{1}, true
+		}
+
+		return particleBool, []byte{0}, true
+	}
+
+	return 0, nil, false
+}
+
+// Returns message of result code. If record is set, its generation, void time & bins are returned.
+// Bin values [Store] cannot send (e.g. seeded lists) fail the command with [types.UNSUPPORTED_FEATURE].
+//
+// [types.UNSUPPORTED_FEATURE]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#UNSUPPORTED_FEATURE
+func messageResponse(code types.ResultCode, record *aerospike.Record) []byte {
+	header := make([]byte, messageHeaderSize)
+	header[0] = messageHeaderSize
+	header[3] = info3Last
+	header[5] = byte(code)
+
+	if record == nil {
+		return writeProto(protoTypeMessage, header)
+	}
+
+	binary.BigEndian.PutUint32(header[6:], record.Generation)
+	binary.BigEndian.PutUint32(header[10:], voidTime(record.Expiration))
+
+	var ops []byte
+	for name, value := range record.Bins {
+		particleType, data, ok := writeParticle(value)
+		if !ok {
+			return messageResponse(types.UNSUPPORTED_FEATURE, nil)
+		}
+
+		op := make([]byte, 8, 8+len(name)+len(data))
+		binary.BigEndian.PutUint32(op, uint32(4+len(name)+len(data)))
+		op[4] = opRead
+		op[5] = particleType
+		op[7] = byte(len(name))
+
+		ops = append(ops, append(append(op, name...), data...)...)
+	}
+
+	binary.BigEndian.PutUint16(header[20:], uint16(len(record.Bins)))
+	return writeProto(protoTypeMessage, append(header, ops...))
+}
+
+// Returns void time of TTL: seconds since Aerospike epoch record expires at, 0 if it never expires.
+func voidTime(ttl uint32) uint32 {
+	if ttl == aerospike.TTLDontExpire {
+		return 0
+	}
+
+	return uint32(time.Now().Unix() - types.CITRUSLEAF_EPOCH + int64(ttl))
+}
+
+// Returns result code of error, [types.SERVER_ERROR] if error is not an [aerospike.AerospikeError].
+//
+// [types.SERVER_ERROR]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6/types#SERVER_ERROR
+// [aerospike.AerospikeError]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#AerospikeError
+func resultCode(err error) types.ResultCode {
+	var unsupportedErr *UnsupportedOperationError
+	if errors.As(err, &unsupportedErr) {
+		return types.UNSUPPORTED_FEATURE
+	}
+
+	var aerospikeErr *aerospike.AerospikeError
+	if errors.As(err, &aerospikeErr) {
+		return aerospikeErr.ResultCode
+	}
+
+	return types.SERVER_ERROR
+}
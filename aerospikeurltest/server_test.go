@@ -0,0 +1,438 @@
+package aerospikeurltest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	aerospikeurl "github.com/tiptophelmet/aerospike-url"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/failover"
+	"github.com/tiptophelmet/aerospike-url/healthcheck"
+)
+
+// Starts server closed at the end of the test.
+func startServer(t *testing.T, options *ServerOptions) *Server {
+	t.Helper()
+
+	server, err := NewServer(options)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+// Builds client of Aerospike URL closed at the end of the test.
+func buildServerClient(t *testing.T, url string) *aerospike.Client {
+	t.Helper()
+
+	factory, err := aerospikeurl.Parse(url)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	client, err := factory.BuildClient()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestServerBuildClient(t *testing.T) {
+	server := startServer(t, nil)
+	client := buildServerClient(t, server.URL("test"))
+
+	if !client.IsConnected() || len(client.GetNodes()) != 1 {
+		t.Fatalf("got: %v nodes, want: client connected to 1 node", len(client.GetNodes()))
+	}
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	if err := client.Put(nil, key, aerospike.BinMap{"name": "Alice", "age": 30, "score": 9.5, "active": true, "avatar": []byte{1, 2}}); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	record, err := client.Get(nil, key)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if record.Bins["name"] != "Alice" || record.Bins["age"] != 30 || record.Bins["score"] != 9.5 || record.Bins["active"] != true || len(record.Bins["avatar"].([]byte)) != 2 {
+		t.Errorf("got: %v, want: bins written", record.Bins)
+	}
+
+	if record.Generation != 1 || record.Expiration != aerospike.TTLDontExpire {
+		t.Errorf("got: generation %v, expiration %v, want: generation 1, never expires", record.Generation, record.Expiration)
+	}
+
+	if server.Store().Len() != 1 {
+		t.Errorf("got: %v, want: 1 record stored", server.Store().Len())
+	}
+}
+
+func TestServerReadOnlyURL(t *testing.T) {
+	server := startServer(t, nil)
+	connStr := server.URL("test") + "?mode=readonly"
+
+	factory, err := aerospikeurl.Parse(connStr)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	// Read-only mode is enforced by Build & BuildReadOnlyClient
+	roClient, err := factory.Build()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+	defer roClient.Close()
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	if err := roClient.Put(nil, key, aerospike.BinMap{"name": "Alice"}); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	// Paths built on BuildClient refuse read-only URLs, as aerospike.Client cannot refuse writes
+	lazy := factory.BuildLazyClient()
+	defer lazy.Close()
+
+	if _, err := lazy.Client(); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("lazy client got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	if _, err := factory.BuildNamespaceClient(); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("namespace client got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	if _, err := factory.BuildTracedClient(aerofactory.NewSpanRecorder()); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("traced client got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	registry := aerospikeurl.NewRegistry()
+	registry.Register("users", connStr)
+	defer registry.CloseAll()
+
+	if _, err := registry.Client("users"); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("registry got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	if report := healthcheck.RegistryReadiness(registry, healthcheck.Options{}).Report(); report.Clients[0].Error != healthcheck.ErrNotBuilt.Error() {
+		t.Errorf("health check got: %+v, want: client not built", report)
+	}
+
+	if _, err := aerospikeurl.AcquireShared(connStr); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("shared client got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "aerospike-url")
+	os.WriteFile(path, []byte(connStr), 0o600)
+
+	if _, err := aerospikeurl.NewReloader(path); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("reloader got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+
+	failoverClient, err := failover.Parse(connStr + "&failover=" + url.QueryEscape(connStr))
+	if err != nil {
+		t.Fatalf("failover client got: %v, want: error = nil", err)
+	}
+	defer failoverClient.Close()
+
+	if _, err := failoverClient.Get(nil, key); !errors.Is(err, aerofactory.ErrReadOnly) {
+		t.Errorf("failover client got: %v, want: error is aerofactory.ErrReadOnly", err)
+	}
+}
+
+func TestServerWarmUpReport(t *testing.T) {
+	server := startServer(t, nil)
+
+	factory, _ := aerospikeurl.Parse(server.URL("test") + "?warm_up=2")
+
+	client, report, err := factory.BuildClientWithWarmUpReport()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+	defer client.Close()
+
+	if report == nil || report.Requested != 2 || report.Err != nil {
+		t.Errorf("got: %+v, want: report of 2 connections per node", report)
+	}
+}
+
+func TestServerRegistryReadiness(t *testing.T) {
+	server := startServer(t, &ServerOptions{Namespaces: []string{"users", "orders"}})
+
+	registry := aerospikeurl.NewRegistry()
+	registry.Register("users", server.URL("users"))
+	registry.Register("orders", server.URL("orders"))
+	defer registry.CloseAll()
+
+	registry.Client("users")
+	registry.Client("orders")
+
+	// Each connection is probed in its own namespace
+	report := healthcheck.RegistryReadiness(registry, healthcheck.Options{}).Report()
+	if report.Status != healthcheck.StatusUp || len(report.Clients) != 2 {
+		t.Fatalf("got: %+v, want: status up for 2 connections", report)
+	}
+
+	for _, clientStatus := range report.Clients {
+		if clientStatus.Probe == nil || !clientStatus.Probe.OK || clientStatus.Probe.Namespace != clientStatus.Name {
+			t.Errorf("got: %+v, want: successful probe in namespace %s", clientStatus.Probe, clientStatus.Name)
+		}
+	}
+}
+
+func TestServerTracedClientNode(t *testing.T) {
+	server := startServer(t, nil)
+	recorder := aerofactory.NewSpanRecorder()
+	tClient := aerofactory.NewTracedClient(buildServerClient(t, server.URL("test")), recorder)
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	tClient.Put(nil, key, aerospike.BinMap{"name": "Alice"})
+	tClient.Get(nil, key)
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("got: %v spans, want: 2", len(spans))
+	}
+
+	// Successful write does not report its node, read reports node of returned record
+	if spans[0].Node != "" || spans[1].Node != serverNodeName {
+		t.Errorf("got: %q, %q, want: no node for Put, %s for Get", spans[0].Node, spans[1].Node, serverNodeName)
+	}
+}
+
+func TestServerRecordCommands(t *testing.T) {
+	server := startServer(t, nil)
+	client := buildServerClient(t, server.URL("test"))
+
+	key, _ := aerospike.NewKey("test", "counters", "counter-001")
+
+	if exists, err := client.Exists(nil, key); err != nil || exists {
+		t.Errorf("got: %v, %v, want: record does not exist", exists, err)
+	}
+
+	record, err := client.Operate(nil, key, aerospike.AddOp(aerospike.NewBin("hits", 2)), aerospike.AppendOp(aerospike.NewBin("log", "a")), aerospike.GetOp())
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if record.Bins["hits"] != 2 || record.Bins["log"] != "a" {
+		t.Errorf("got: %v, want: hits = 2, log = a", record.Bins)
+	}
+
+	policy := aerospike.NewWritePolicy(0, 60)
+	policy.RecordExistsAction = aerospike.CREATE_ONLY
+	if err := client.Put(policy, key, aerospike.BinMap{"hits": 1}); !err.Matches(types.KEY_EXISTS_ERROR) {
+		t.Errorf("got: %v, want: KEY_EXISTS_ERROR", err)
+	}
+
+	if err := client.Touch(aerospike.NewWritePolicy(0, 60), key); err != nil {
+		t.Errorf("got: %v, want: error = nil", err)
+	}
+
+	header, err := client.GetHeader(nil, key)
+	if err != nil || header.Generation != 2 || len(header.Bins) != 0 || header.Expiration == 0 || header.Expiration > 60 {
+		t.Errorf("got: %v, %v, want: generation 2 with TTL 60 & no bins", header, err)
+	}
+
+	if existed, err := client.Delete(nil, key); err != nil || !existed {
+		t.Errorf("got: %v, %v, want: record existed", existed, err)
+	}
+
+	if _, err := client.Get(nil, key); !errors.Is(err, aerospike.ErrKeyNotFound) {
+		t.Errorf("got: %v, want: error is aerospike.ErrKeyNotFound", err)
+	}
+
+	otherKey, _ := aerospike.NewKey("other", "counters", "counter-001")
+	if err := client.Put(nil, otherKey, aerospike.BinMap{"hits": 1}); !err.Matches(types.INVALID_NAMESPACE) {
+		t.Errorf("got: %v, want: INVALID_NAMESPACE", err)
+	}
+}
+
+func TestServerUnsupportedOperation(t *testing.T) {
+	server := startServer(t, nil)
+	client := buildServerClient(t, server.URL("test"))
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	_, err := client.Operate(nil, key, aerospike.ListAppendOp("tags", "a"))
+	if !err.Matches(types.UNSUPPORTED_FEATURE) {
+		t.Errorf("got: %v, want: UNSUPPORTED_FEATURE", err)
+	}
+}
+
+func TestServerAuth(t *testing.T) {
+	server := startServer(t, &ServerOptions{Users: map[string]string{"aero-user-001": "aerouserpassw123"}})
+
+	client := buildServerClient(t, userURL(server, "aero-user-001", "aerouserpassw123"))
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	if err := client.Put(nil, key, aerospike.BinMap{"name": "Alice"}); err != nil {
+		t.Errorf("got: %v, want: error = nil", err)
+	}
+
+	for _, url := range []string{
+		userURL(server, "aero-user-001", "wrongpassw"),
+		userURL(server, "aero-user-002", "aerouserpassw123"),
+		server.URL("test"),
+	} {
+		factory, err := aerospikeurl.Parse(url)
+		if err != nil {
+			t.Fatalf("got: %v, want: error = nil", err)
+		}
+
+		if client, err := factory.BuildClient(); err == nil {
+			client.Close()
+			t.Errorf("got: error = nil, want: %s refused", url)
+		}
+	}
+}
+
+// Returns Aerospike URL of server "test" namespace with user credentials.
+func userURL(server *Server, user string, password string) string {
+	return fmt.Sprintf("aerospike://%s:%s@%s:%d/test", user, password, server.Hostname(), server.Port())
+}
+
+func TestServerClusterName(t *testing.T) {
+	server := startServer(t, &ServerOptions{ClusterName: "aero-cluster-001"})
+
+	buildServerClient(t, server.URL("test")+"?cluster_name=aero-cluster-001")
+
+	factory, _ := aerospikeurl.Parse(server.URL("test") + "?cluster_name=aero-cluster-002")
+	if client, err := factory.BuildClient(); err == nil {
+		client.Close()
+		t.Error("got: error = nil, want: cluster name mismatch")
+	}
+}
+
+func TestServerPreflight(t *testing.T) {
+	server := startServer(t, &ServerOptions{Namespaces: []string{"aero-namespace-001", "aero-namespace-002"}})
+
+	buildServerClient(t, server.URL("aero-namespace-002")+"?verify_namespace=true")
+
+	factory, _ := aerospikeurl.Parse(server.URL("aero-namespace-003") + "?verify_namespace=true")
+	_, err := factory.BuildClient()
+
+	var preflightErr *aerofactory.PreflightError
+	if !errors.As(err, &preflightErr) || len(preflightErr.NodesMissingNamespace) != 1 {
+		t.Errorf("got: %v, want: *aerofactory.PreflightError with 1 node missing namespace", err)
+	}
+}
+
+func TestServerTLS(t *testing.T) {
+	caFile, certificate := generateCertificate(t, "aero-tls-001")
+	server := startServer(t, &ServerOptions{TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}}})
+
+	factory := &aerofactory.AerospikeClientFactory{}
+	factory.SetAddress(server.Hostname(), server.Port(), "test")
+	factory.SetHosts(server.Host("aero-tls-001"))
+	factory.SetTLSFiles(&aerofactory.TLSFiles{CAFile: caFile})
+
+	client, err := factory.BuildClient()
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+	defer client.Close()
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	if err := client.Put(nil, key, aerospike.BinMap{"name": "Alice"}); err != nil {
+		t.Errorf("got: %v, want: error = nil", err)
+	}
+
+	factory.SetHosts(server.Host("aero-tls-002"))
+	if client, err := factory.BuildClient(); err == nil {
+		client.Close()
+		t.Error("got: error = nil, want: certificate not valid for aero-tls-002")
+	}
+}
+
+func TestServerRetry(t *testing.T) {
+	server := startServer(t, nil)
+	client := buildServerClient(t, server.URL("test"))
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+	client.Put(nil, key, aerospike.BinMap{"name": "Alice"})
+
+	// Default read policy sends command twice at most
+	policy := aerospike.NewPolicy()
+	policy.SleepBetweenRetries = 0
+
+	server.DropNext(1)
+	if _, err := client.Get(policy, key); err != nil {
+		t.Errorf("got: %v, want: error = nil after retry", err)
+	}
+
+	policy.MaxRetries = 0
+
+	server.DropNext(1)
+	if _, err := client.Get(policy, key); err == nil {
+		t.Error("got: error = nil, want: dropped connection error without retries")
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+	server := startServer(t, nil)
+	client := buildServerClient(t, server.URL("test"))
+
+	key, _ := aerospike.NewKey("test", "users", "user-001")
+
+	policy := aerospike.NewPolicy()
+	policy.TotalTimeout = 50 * time.Millisecond
+	policy.SocketTimeout = 50 * time.Millisecond
+	policy.MaxRetries = 0
+
+	server.SetLatency(200 * time.Millisecond)
+	if _, err := client.Get(policy, key); !err.Matches(types.TIMEOUT) {
+		t.Errorf("got: %v, want: TIMEOUT", err)
+	}
+}
+
+// Generates self-signed certificate valid for DNS name & writes it into PEM file, returning its path & the certificate.
+func generateCertificate(t *testing.T, name string) (string, tls.Certificate) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	return caFile, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: privateKey}
+}
@@ -3,11 +3,19 @@
 package aerourl
 
 import (
+	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
+// Max length of Aerospike set name in bytes.
+const maxSetLength = 63
+
+// URL schemes selecting in-memory client (See: [AerospikeURL.InMemory]).
+var inMemorySchemes = map[string]bool{"mem": true, "aerospike+mem": true}
+
 // Processes connection string, initializes, resolves & returns [aerourl.AerospikeURL].
 // Returns error, if connection string is empty or not resolved.
 func Init(connStr string) (*AerospikeURL, error) {
@@ -38,6 +46,7 @@ type AerospikeURL struct {
 	hostname  string
 	port      int
 	namespace string
+	set       string
 
 	url *url.URL
 }
@@ -45,7 +54,7 @@ type AerospikeURL struct {
 // Resolves min required data required for creating Aerospike DB client.
 // If URL is missing a required part - error is returned, otherwise it is nil.
 func (aeroURL *AerospikeURL) resolve() error {
-	if aeroURL.url.Scheme != "aerospike" {
+	if aeroURL.url.Scheme != "aerospike" && !aeroURL.InMemory() {
 		return ErrInvalidScheme
 	}
 
@@ -53,14 +62,21 @@ func (aeroURL *AerospikeURL) resolve() error {
 		return err
 	}
 
-	if err := aeroURL.resolvePort(); err != nil {
-		return err
+	// In-memory client does not connect anywhere, so port is optional
+	if !aeroURL.InMemory() || aeroURL.url.Port() != "" {
+		if err := aeroURL.resolvePort(); err != nil {
+			return err
+		}
 	}
 
 	if err := aeroURL.resolveNamespace(); err != nil {
 		return err
 	}
 
+	if err := aeroURL.resolveSet(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -93,7 +109,7 @@ func (aeroURL *AerospikeURL) resolvePort() error {
 //
 // [Aerospike namespace] https://docs.aerospike.com/server/architecture/data-model#namespaces
 func (aeroURL *AerospikeURL) resolveNamespace() error {
-	pathParts := strings.Split(aeroURL.url.Path, "/")
+	pathParts := aeroURL.pathParts()
 	if len(pathParts) == 1 {
 		return ErrEmptyNamespace
 	}
@@ -115,12 +131,53 @@ func (aeroURL *AerospikeURL) resolveNamespace() error {
 	return nil
 }
 
+// Validates and sets optional [Aerospike set] following namespace in URL path, e.g. `/aero-namespace-001/users`.
+// If set name breaks Aerospike set naming rules or path has segments after set, error is returned.
+//
+// [Aerospike set] https://docs.aerospike.com/server/architecture/data-model#sets
+func (aeroURL *AerospikeURL) resolveSet() error {
+	pathParts := aeroURL.pathParts()
+	if len(pathParts) < 3 {
+		return nil
+	}
+
+	if len(pathParts) > 3 {
+		return fmt.Errorf("%w: /%s", ErrExtraPathSegments, strings.Join(pathParts[3:], "/"))
+	}
+
+	set := pathParts[2]
+
+	if len(set) > maxSetLength {
+		return fmt.Errorf("%w: %s is longer than %d bytes", ErrInvalidSet, set, maxSetLength)
+	}
+
+	for _, char := range set {
+		if char == ':' || char == ';' || unicode.IsSpace(char) || unicode.IsControl(char) {
+			return fmt.Errorf("%w: %s contains %q", ErrInvalidSet, set, char)
+		}
+	}
+
+	aeroURL.set = set
+	return nil
+}
+
+// Splits URL path into segments. A single trailing slash is ignored,
+// so `/aero-namespace-001/` & `/aero-namespace-001/users/` are accepted.
+func (aeroURL *AerospikeURL) pathParts() []string {
+	pathParts := strings.Split(aeroURL.url.Path, "/")
+	if len(pathParts) > 2 && pathParts[len(pathParts)-1] == "" {
+		pathParts = pathParts[:len(pathParts)-1]
+	}
+
+	return pathParts
+}
+
 // Returns resolved Aerospike hostname.
 func (aerourl *AerospikeURL) Hostname() string {
 	return aerourl.hostname
 }
 
-// Returns resolved Aerospike hostname.
+// Returns resolved Aerospike port or 0, if in-memory URL has no port.
 func (aerourl *AerospikeURL) Port() int {
 	return aerourl.port
 }
@@ -132,6 +189,19 @@ func (aerourl *AerospikeURL) Namespace() string {
 	return aerourl.namespace
 }
 
+// Returns resolved [Aerospike set] or empty string, if URL path has no set.
+//
+// [Aerospike set] https://docs.aerospike.com/server/architecture/data-model#sets
+func (aerourl *AerospikeURL) Set() string {
+	return aerourl.set
+}
+
+// Reports whether URL selects in-memory client with `mem://` or `aerospike+mem://` scheme,
+// e.g. `mem://orders/aero-namespace-001`. Hostname names the in-memory store, port is optional.
+func (aerourl *AerospikeURL) InMemory() bool {
+	return aerourl.url != nil && inMemorySchemes[aerourl.url.Scheme]
+}
+
 // Retrieves underlying [net/url.URL].
 // Empty [net/url.URL] is returned, if it was not initialized & validated at [aerourl.Init].
 //
@@ -143,3 +213,36 @@ func (aeroURL *AerospikeURL) GetNetURL() *url.URL {
 
 	return aeroURL.url
 }
+
+// Returns URL with passwords redacted, including passwords of URLs nested in query parameters,
+// e.g. standby clusters in `failover` parameters.
+func (aeroURL *AerospikeURL) Redacted() string {
+	return redact(aeroURL.GetNetURL())
+}
+
+// Returns URL with passwords of its own & of URLs nested in query parameters redacted.
+func redact(netURL *url.URL) string {
+	query := netURL.Query()
+	nestedRedacted := false
+
+	for _, values := range query {
+		for i, value := range values {
+			nested, err := url.Parse(value)
+			if err != nil || nested.Scheme == "" {
+				continue
+			}
+
+			if redacted := redact(nested); redacted != value {
+				values[i] = redacted
+				nestedRedacted = true
+			}
+		}
+	}
+
+	redacted := *netURL
+	if nestedRedacted {
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.Redacted()
+}
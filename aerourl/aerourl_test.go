@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -127,16 +128,82 @@ func TestInitInvalidNamespaceFromCompatiblePath(t *testing.T) {
 	}
 }
 
-func TestInitNamespaceFromCompatiblePath(t *testing.T) {
+func TestInitExtraPathSegments(t *testing.T) {
 	connStr := "aerospike://127.0.0.1:3000/aero-namespace-001/random/compat/path"
 	aeroURL, err := Init(connStr)
 
-	if err != nil {
-		t.Fatalf(`got: %v, want: error = nil`, err)
+	if !errors.Is(err, ErrExtraPathSegments) {
+		t.Fatalf(`got: %v, want: error is aerourl.ErrExtraPathSegments`, err)
 	}
 
-	if aeroURL == nil {
-		t.Fatalf(`got: %v, want: *AerospikeURL != nil`, aeroURL)
+	if aeroURL != nil {
+		t.Fatalf(`got: %v, want: *AerospikeURL == nil`, aeroURL)
+	}
+}
+
+func TestInitSet(t *testing.T) {
+	for connStr, set := range map[string]string{
+		"aerospike://127.0.0.1:3000/aero-namespace-001":        "",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/":       "",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users":  "users",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/": "users",
+	} {
+		aeroURL, err := Init(connStr)
+		if err != nil {
+			t.Fatalf(`got: %v, want: error = nil`, err)
+		}
+
+		if aeroURL.Set() != set {
+			t.Errorf(`got: %v, want: %v`, aeroURL.Set(), set)
+		}
+	}
+}
+
+func TestInitInvalidSet(t *testing.T) {
+	for _, set := range []string{"us:ers", "us;ers", "us%20ers", strings.Repeat("s", 64)} {
+		aeroURL, err := Init("aerospike://127.0.0.1:3000/aero-namespace-001/" + set)
+
+		if !errors.Is(err, ErrInvalidSet) {
+			t.Fatalf(`got: %v, want: error is aerourl.ErrInvalidSet`, err)
+		}
+
+		if aeroURL != nil {
+			t.Fatalf(`got: %v, want: *AerospikeURL == nil`, aeroURL)
+		}
+	}
+}
+
+func TestInitInMemory(t *testing.T) {
+	for connStr, port := range map[string]int{
+		"mem://orders/aero-namespace-001":                 0,
+		"aerospike+mem://orders/aero-namespace-001/users": 0,
+		"mem://orders:3000/aero-namespace-001":            3000,
+	} {
+		aeroURL, err := Init(connStr)
+		if err != nil {
+			t.Fatalf(`got: %v, want: error = nil`, err)
+		}
+
+		if !aeroURL.InMemory() || aeroURL.Hostname() != "orders" || aeroURL.Port() != port {
+			t.Errorf(`got: %v, %v, %v, want: in-memory URL of orders:%v`, aeroURL.InMemory(), aeroURL.Hostname(), aeroURL.Port(), port)
+		}
+	}
+
+	aeroURL, _ := Init("aerospike://127.0.0.1:3000/aero-namespace-001")
+	if aeroURL.InMemory() {
+		t.Errorf(`got: %v, want: false`, aeroURL.InMemory())
+	}
+}
+
+func TestInitInMemoryEmptyNamespace(t *testing.T) {
+	aeroURL, err := Init("mem://orders")
+
+	if !errors.Is(err, ErrEmptyNamespace) {
+		t.Fatalf(`got: %v, want: error is aerourl.ErrEmptyNamespace`, err)
+	}
+
+	if aeroURL != nil {
+		t.Fatalf(`got: %v, want: *AerospikeURL == nil`, aeroURL)
 	}
 }
 
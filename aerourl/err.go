@@ -9,7 +9,7 @@ var (
 	ErrEmptyConnStr = errors.New("empty aerospike connection string")
 
 	// Aerospike URL validation failed due to invalid URL scheme
-	ErrInvalidScheme = errors.New("invalid url scheme, want: aerospike://")
+	ErrInvalidScheme = errors.New("invalid url scheme, want: aerospike://, mem:// or aerospike+mem://")
 
 	// Aerospike URL validation failed due to empty Aerospike DB hostname
 	ErrEmptyHostname = errors.New("aerospike hostname cannot be empty")
@@ -22,4 +22,10 @@ var (
 
 	// Aerospike URL validation failed due to invalid Aerospike namespace
 	ErrInvalidNamespace = errors.New("aerospike namespace is invalid")
+
+	// Aerospike URL validation failed due to invalid Aerospike set
+	ErrInvalidSet = errors.New("aerospike set is invalid")
+
+	// Aerospike URL validation failed due to path segments after Aerospike set
+	ErrExtraPathSegments = errors.New("aerospike url path has extra segments, want: /namespace[/set]")
 )
@@ -0,0 +1,348 @@
+// Astools package.
+// Aerospike tools config (astools.conf) is read into & written from [aerofactory.AerospikeClientFactory] here,
+// so application & tooling (`aql`, `asadm`, `asinfo`) configs cannot drift.
+//
+// Supported `[cluster]` keys: `host`, `port`, `user`, `password`, `auth`,
+// `tls-enable`, `tls-name`, `tls-cafile`, `tls-certfile` & `tls-keyfile`.
+// Namespace is not part of tools config & must be set on the factory separately.
+package astools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Default Aerospike DB port, used for hosts without port.
+const defaultPort = 3000
+
+// Environment variable Aerospike tools read password from, as passed by [AQLArgs] & [AsinfoArgs] (`--password=env:<name>`).
+const PasswordEnv = "ASTOOLS_PASSWORD"
+
+// Reads tools config file into [aerofactory.AerospikeClientFactory] (See: [astools.Read]).
+func ReadFile(path string, instance string) (*aerofactory.AerospikeClientFactory, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(file, instance)
+}
+
+// Reads `[cluster]` section of tools config into [aerofactory.AerospikeClientFactory].
+// If instance is not empty, `[cluster_<instance>]` section is read instead (same as `--instance` of Aerospike tools).
+// Returns error, if config cannot be parsed or has no host.
+func Read(r io.Reader, instance string) (*aerofactory.AerospikeClientFactory, error) {
+	sections, err := parseTOML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, ok := sections[sectionName(instance)]
+	if !ok {
+		return nil, fmt.Errorf("%w: [%s]", ErrNoClusterSection, sectionName(instance))
+	}
+
+	port := defaultPort
+	if portStr, ok := cluster["port"]; ok {
+		if port, err = strconv.Atoi(portStr); err != nil {
+			return nil, fmt.Errorf("%w: port %s", ErrInvalidHost, portStr)
+		}
+	}
+
+	hosts, err := parseHosts(cluster["host"], port)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := aerospike.NewClientPolicy()
+	policy.User = cluster["user"]
+	policy.Password = cluster["password"]
+
+	if auth, ok := cluster["auth"]; ok {
+		if policy.AuthMode, err = parseAuth(auth); err != nil {
+			return nil, err
+		}
+	}
+
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+	clientFactory.SetAddress(hosts[0].Name, hosts[0].Port, "")
+	clientFactory.SetClientPolicy(policy)
+
+	if len(hosts) > 1 || hosts[0].TLSName != "" {
+		clientFactory.SetHosts(hosts...)
+	}
+
+	if tlsEnable, _ := strconv.ParseBool(cluster["tls-enable"]); tlsEnable {
+		clientFactory.SetTLSFiles(&aerofactory.TLSFiles{
+			CAFile:     cluster["tls-cafile"],
+			CertFile:   cluster["tls-certfile"],
+			KeyFile:    cluster["tls-keyfile"],
+			ServerName: cluster["tls-name"],
+		})
+	}
+
+	return clientFactory, nil
+}
+
+// Writes [aerofactory.AerospikeClientFactory] as `[cluster]` section of tools config.
+// If instance is not empty, `[cluster_<instance>]` section is written instead.
+//
+// Password is written as is, so the resulting file must be protected the same way as other tools configs.
+func Write(w io.Writer, clientFactory *aerofactory.AerospikeClientFactory, instance string) error {
+	lines := []string{fmt.Sprintf("[%s]", sectionName(instance))}
+
+	lines = append(lines, fmt.Sprintf("host = %s", strconv.Quote(formatHosts(clientFactory.GetHosts()))))
+
+	if policy := clientFactory.GetClientPolicy(); policy != nil {
+		if policy.User != "" {
+			lines = append(lines, fmt.Sprintf("user = %s", strconv.Quote(policy.User)))
+		}
+
+		if policy.Password != "" {
+			lines = append(lines, fmt.Sprintf("password = %s", strconv.Quote(policy.Password)))
+		}
+
+		lines = append(lines, fmt.Sprintf("auth = %s", strconv.Quote(formatAuth(policy.AuthMode))))
+	}
+
+	if tlsFiles := clientFactory.GetTLSFiles(); tlsFiles != nil {
+		lines = append(lines, "tls-enable = true")
+
+		for _, option := range tlsOptions(tlsFiles) {
+			lines = append(lines, fmt.Sprintf("%s = %s", option[0], strconv.Quote(option[1])))
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// Returns `aql` command-line arguments equivalent to [aerofactory.AerospikeClientFactory].
+//
+// Password is not included, so it does not leak through process list: tools read it from [PasswordEnv] (See: [Env]).
+func AQLArgs(clientFactory *aerofactory.AerospikeClientFactory) []string {
+	args := []string{"--host=" + formatHosts(clientFactory.GetHosts())}
+
+	return append(args, authArgs(clientFactory)...)
+}
+
+// Returns `asinfo` command-line arguments equivalent to [aerofactory.AerospikeClientFactory].
+// As `asinfo` talks to a single node, only the first seed host is used.
+//
+// Password is not included, so it does not leak through process list: tools read it from [PasswordEnv] (See: [Env]).
+func AsinfoArgs(clientFactory *aerofactory.AerospikeClientFactory) []string {
+	host := clientFactory.GetHosts()[0]
+
+	args := []string{"--host=" + host.Name, "--port=" + strconv.Itoa(host.Port)}
+
+	tlsFiles := clientFactory.GetTLSFiles()
+	if host.TLSName != "" && tlsFiles != nil && tlsFiles.ServerName == "" {
+		args = append(args, "--tls-name="+host.TLSName)
+	}
+
+	return append(args, authArgs(clientFactory)...)
+}
+
+// Returns auth & TLS command-line arguments shared by Aerospike tools.
+func authArgs(clientFactory *aerofactory.AerospikeClientFactory) []string {
+	args := []string{}
+
+	if policy := clientFactory.GetClientPolicy(); policy != nil {
+		if policy.User != "" {
+			args = append(args, "--user="+policy.User)
+		}
+
+		if policy.Password != "" {
+			args = append(args, "--password=env:"+PasswordEnv)
+		}
+
+		if policy.User != "" || policy.AuthMode != aerospike.AuthModeInternal {
+			args = append(args, "--auth="+formatAuth(policy.AuthMode))
+		}
+	}
+
+	if tlsFiles := clientFactory.GetTLSFiles(); tlsFiles != nil {
+		args = append(args, "--tls-enable")
+
+		for _, option := range tlsOptions(tlsFiles) {
+			args = append(args, fmt.Sprintf("--%s=%s", option[0], option[1]))
+		}
+	}
+
+	return args
+}
+
+// Returns environment of Aerospike tools run with [AQLArgs] or [AsinfoArgs], e.g. to append to `exec.Cmd.Env`:
+// password in [PasswordEnv] or nothing, if password is not set.
+//
+// Password is included as is, so environment must not be logged.
+func Env(clientFactory *aerofactory.AerospikeClientFactory) []string {
+	if policy := clientFactory.GetClientPolicy(); policy != nil && policy.Password != "" {
+		return []string{PasswordEnv + "=" + policy.Password}
+	}
+
+	return nil
+}
+
+// Returns non-empty TLS options as tools config key & value pairs.
+func tlsOptions(tlsFiles *aerofactory.TLSFiles) [][2]string {
+	options := [][2]string{}
+
+	for _, option := range [][2]string{
+		{"tls-name", tlsFiles.ServerName},
+		{"tls-cafile", tlsFiles.CAFile},
+		{"tls-certfile", tlsFiles.CertFile},
+		{"tls-keyfile", tlsFiles.KeyFile},
+	} {
+		if option[1] != "" {
+			options = append(options, option)
+		}
+	}
+
+	return options
+}
+
+// Returns cluster section name for instance.
+func sectionName(instance string) string {
+	if instance == "" {
+		return "cluster"
+	}
+
+	return "cluster_" + instance
+}
+
+// Parses comma-separated `host[:tls-name][:port]` list into [aerospike.Host] list.
+// IPv6 addresses must be enclosed in brackets.
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func parseHosts(hostsStr string, port int) ([]*aerospike.Host, error) {
+	hosts := []*aerospike.Host{}
+
+	for _, hostStr := range strings.Split(hostsStr, ",") {
+		hostStr = strings.TrimSpace(hostStr)
+		if hostStr == "" {
+			continue
+		}
+
+		host, err := parseHost(hostStr, port)
+		if err != nil {
+			return nil, err
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) == 0 {
+		return nil, ErrNoHost
+	}
+
+	return hosts, nil
+}
+
+// Parses `host[:tls-name][:port]` into [aerospike.Host].
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func parseHost(hostStr string, port int) (*aerospike.Host, error) {
+	name, rest := hostStr, ""
+
+	if strings.HasPrefix(hostStr, "[") {
+		end := strings.Index(hostStr, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHost, hostStr)
+		}
+
+		name, rest = hostStr[1:end], strings.TrimPrefix(hostStr[end+1:], ":")
+	} else if before, after, found := strings.Cut(hostStr, ":"); found {
+		name, rest = before, after
+	}
+
+	host := aerospike.NewHost(name, port)
+	parts := []string{}
+	if rest != "" {
+		parts = strings.Split(rest, ":")
+	}
+
+	switch len(parts) {
+	case 0:
+	case 1:
+		if parsedPort, err := strconv.Atoi(parts[0]); err == nil {
+			host.Port = parsedPort
+		} else {
+			host.TLSName = parts[0]
+		}
+	case 2:
+		parsedPort, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHost, hostStr)
+		}
+
+		host.TLSName, host.Port = parts[0], parsedPort
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHost, hostStr)
+	}
+
+	if host.Name == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHost, hostStr)
+	}
+
+	return host, nil
+}
+
+// Formats [aerospike.Host] list as comma-separated `host[:tls-name]:port` list.
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func formatHosts(hosts []*aerospike.Host) string {
+	hostStrs := make([]string, 0, len(hosts))
+
+	for _, host := range hosts {
+		name := host.Name
+		if strings.Contains(name, ":") {
+			name = "[" + name + "]"
+		}
+
+		if host.TLSName != "" {
+			name += ":" + host.TLSName
+		}
+
+		hostStrs = append(hostStrs, name+":"+strconv.Itoa(host.Port))
+	}
+
+	return strings.Join(hostStrs, ",")
+}
+
+// Parses tools auth mode into [aerospike.AuthMode].
+//
+// [aerospike.AuthMode]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#AuthMode
+func parseAuth(auth string) (aerospike.AuthMode, error) {
+	switch strings.ToUpper(auth) {
+	case "INTERNAL":
+		return aerospike.AuthModeInternal, nil
+	case "EXTERNAL", "EXTERNAL_INSECURE":
+		return aerospike.AuthModeExternal, nil
+	case "PKI":
+		return aerospike.AuthModePKI, nil
+	}
+
+	return aerospike.AuthModeInternal, fmt.Errorf("%w: %s", ErrInvalidAuth, auth)
+}
+
+// Formats [aerospike.AuthMode] as tools auth mode.
+//
+// [aerospike.AuthMode]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#AuthMode
+func formatAuth(authMode aerospike.AuthMode) string {
+	switch authMode {
+	case aerospike.AuthModeExternal:
+		return "EXTERNAL"
+	case aerospike.AuthModePKI:
+		return "PKI"
+	default:
+		return "INTERNAL"
+	}
+}
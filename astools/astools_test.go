@@ -0,0 +1,148 @@
+package astools
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+const toolsConfig = `
+# Operators' tools config
+[cluster]
+host = "10.0.0.1:3000"
+user = "aero-user-001"
+password = "aerouser#passw"  # hash inside quotes is kept
+auth = "INTERNAL"
+
+[cluster_east]
+host = "10.1.0.1:aero-tls-001:4333,10.1.0.2:aero-tls-001:4333"
+auth = "PKI"
+tls-enable = true
+tls-name = "aero-tls-001"
+tls-cafile = "/etc/aerospike/ca.pem"
+
+[aql]
+timeout = 1000
+`
+
+func TestRead(t *testing.T) {
+	clientFactory, err := Read(strings.NewReader(toolsConfig), "")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if clientFactory.GetHostname() != "10.0.0.1" || clientFactory.GetPort() != 3000 {
+		t.Errorf("got: %v:%v, want: 10.0.0.1:3000", clientFactory.GetHostname(), clientFactory.GetPort())
+	}
+
+	policy := clientFactory.GetClientPolicy()
+	if policy.User != "aero-user-001" || policy.Password != "aerouser#passw" || policy.AuthMode != aerospike.AuthModeInternal {
+		t.Errorf("got: %v, want: policy from [cluster]", policy)
+	}
+
+	if clientFactory.GetTLSFiles() != nil {
+		t.Errorf("got: %v, want: TLS disabled", clientFactory.GetTLSFiles())
+	}
+}
+
+func TestReadInstance(t *testing.T) {
+	clientFactory, err := Read(strings.NewReader(toolsConfig), "east")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	hosts := clientFactory.GetHosts()
+	if len(hosts) != 2 || hosts[1].Name != "10.1.0.2" || hosts[1].TLSName != "aero-tls-001" || hosts[1].Port != 4333 {
+		t.Errorf("got: %v, want: 2 TLS hosts", hosts)
+	}
+
+	if clientFactory.GetClientPolicy().AuthMode != aerospike.AuthModePKI {
+		t.Errorf("got: %v, want: aerospike.AuthModePKI", clientFactory.GetClientPolicy().AuthMode)
+	}
+
+	tlsFiles := clientFactory.GetTLSFiles()
+	if tlsFiles == nil || tlsFiles.CAFile != "/etc/aerospike/ca.pem" || tlsFiles.ServerName != "aero-tls-001" {
+		t.Errorf("got: %v, want: TLS files from [cluster_east]", tlsFiles)
+	}
+}
+
+func TestReadMissingInstance(t *testing.T) {
+	_, err := Read(strings.NewReader(toolsConfig), "west")
+	if !errors.Is(err, ErrNoClusterSection) {
+		t.Errorf("got: %v, want: error is astools.ErrNoClusterSection", err)
+	}
+}
+
+func TestReadInvalidAuth(t *testing.T) {
+	_, err := Read(strings.NewReader("[cluster]\nhost = \"127.0.0.1\"\nauth = \"LDAP\""), "")
+	if !errors.Is(err, ErrInvalidAuth) {
+		t.Errorf("got: %v, want: error is astools.ErrInvalidAuth", err)
+	}
+}
+
+func TestReadDefaultPort(t *testing.T) {
+	clientFactory, err := Read(strings.NewReader("[cluster]\nhost = \"127.0.0.1\"\nport = 3100"), "")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if clientFactory.GetPort() != 3100 {
+		t.Errorf("got: %v, want: 3100", clientFactory.GetPort())
+	}
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	clientFactory, _ := Read(strings.NewReader(toolsConfig), "east")
+
+	var buf bytes.Buffer
+	if err := Write(&buf, clientFactory, "east"); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	readFactory, err := Read(&buf, "east")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if !reflect.DeepEqual(readFactory.GetHosts(), clientFactory.GetHosts()) ||
+		!reflect.DeepEqual(readFactory.GetTLSFiles(), clientFactory.GetTLSFiles()) {
+		t.Errorf("got: %v, want: %v", readFactory.GetHosts(), clientFactory.GetHosts())
+	}
+}
+
+func TestAQLArgs(t *testing.T) {
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+	clientFactory.SetAddress("127.0.0.1", 3000, "aero-namespace-001")
+
+	policy := aerospike.NewClientPolicy()
+	policy.User = "aero-user-001"
+	policy.Password = "aerouserpassw123"
+	clientFactory.SetClientPolicy(policy)
+
+	want := []string{"--host=127.0.0.1:3000", "--user=aero-user-001", "--password=env:ASTOOLS_PASSWORD", "--auth=INTERNAL"}
+	if got := AQLArgs(clientFactory); !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if got := Env(clientFactory); !reflect.DeepEqual(got, []string{"ASTOOLS_PASSWORD=aerouserpassw123"}) {
+		t.Errorf("got: %v, want: password in environment", got)
+	}
+}
+
+func TestAsinfoArgs(t *testing.T) {
+	clientFactory, _ := Read(strings.NewReader(toolsConfig), "east")
+
+	want := []string{"--host=10.1.0.1", "--port=4333", "--auth=PKI", "--tls-enable", "--tls-name=aero-tls-001", "--tls-cafile=/etc/aerospike/ca.pem"}
+	if got := AsinfoArgs(clientFactory); !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	if got := Env(clientFactory); got != nil {
+		t.Errorf("got: %v, want: no environment without password", got)
+	}
+}
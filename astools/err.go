@@ -0,0 +1,22 @@
+// Astools package.
+// Aerospike tools config (astools.conf) is read into & written from [aerofactory.AerospikeClientFactory] here.
+package astools
+
+import "errors"
+
+var (
+	// Tools config line is neither a section, a `key = value` pair nor a comment
+	ErrInvalidLine = errors.New("invalid astools config line")
+
+	// Tools config has no cluster section for requested instance
+	ErrNoClusterSection = errors.New("astools config has no cluster section")
+
+	// Tools config cluster section has no host
+	ErrNoHost = errors.New("astools config cluster section has no host")
+
+	// Tools config host is not in `host[:tls-name][:port]` format
+	ErrInvalidHost = errors.New("invalid astools host, want: host[:tls-name][:port]")
+
+	// Tools config auth mode is not one of INTERNAL, EXTERNAL, EXTERNAL_INSECURE or PKI
+	ErrInvalidAuth = errors.New("invalid astools auth, want: INTERNAL, EXTERNAL, EXTERNAL_INSECURE or PKI")
+)
@@ -0,0 +1,95 @@
+package astools
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parses the TOML subset astools.conf is written in:
+// `[section]` headers, `key = value` pairs with string, integer & boolean values, and `#` comments.
+// Returns values keyed by section name, then by key.
+func parseTOML(r io.Reader) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%w %d: %s", ErrInvalidLine, lineNum, line)
+			}
+
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if sections[section] == nil {
+				sections[section] = map[string]string{}
+			}
+
+			continue
+		}
+
+		key, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%w %d: %s", ErrInvalidLine, lineNum, line)
+		}
+
+		value, err := parseTOMLValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("%w %d: %s", ErrInvalidLine, lineNum, line)
+		}
+
+		if sections[section] == nil {
+			sections[section] = map[string]string{}
+		}
+
+		sections[section][strings.TrimSpace(key)] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// Parses TOML value: quoted strings are unquoted, integers & booleans are kept as is.
+func parseTOMLValue(rawValue string) (string, error) {
+	if strings.HasPrefix(rawValue, `"`) {
+		return strconv.Unquote(rawValue)
+	}
+
+	if strings.HasPrefix(rawValue, "'") && strings.HasSuffix(rawValue, "'") && len(rawValue) >= 2 {
+		return rawValue[1 : len(rawValue)-1], nil
+	}
+
+	if rawValue == "" {
+		return "", strconv.ErrSyntax
+	}
+
+	return rawValue, nil
+}
+
+// Removes `#` comment from line, keeping `#` inside quoted strings.
+func stripComment(line string) string {
+	quote := rune(0)
+
+	for i, char := range line {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\''):
+			quote = char
+		case quote == 0 && char == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
@@ -0,0 +1,360 @@
+// Clientoptions package.
+// Client options beyond [aerospike.ClientPolicy] (mode, preflight checks, warm-up, ...) are parsed from [aerourl.AerospikeURL] here.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+package clientoptions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+// Parses client options from validated [aerourl.AerospikeURL] into [aerofactory.AerospikeClientFactory].
+// Unlike client policy properties, invalid options are not ignored: error is returned,
+// so a typo cannot silently turn off a safety option.
+func Parse(aeroURL *aerourl.AerospikeURL, clientFactory *aerofactory.AerospikeClientFactory) error {
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+
+	if err := parser.Mode(); err != nil {
+		return err
+	}
+
+	if err := parser.VerifyNamespace(); err != nil {
+		return err
+	}
+
+	parser.RequireRoles()
+
+	if err := parser.WarmUp(); err != nil {
+		return err
+	}
+
+	if err := parser.LogLevel(); err != nil {
+		return err
+	}
+
+	if err := parser.Chaos(); err != nil {
+		return err
+	}
+
+	if err := parser.Breaker(); err != nil {
+		return err
+	}
+
+	if err := parser.RateLimits(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Serves as a holder for [aerourl.AerospikeURL] and [aerofactory.AerospikeClientFactory].
+// Has a collection of methods to identify and parse each client option from URL query.
+type ClientOptionsParser struct {
+	aeroURL       *aerourl.AerospikeURL
+	clientFactory *aerofactory.AerospikeClientFactory
+}
+
+// Parses `mode` (See: [aerofactory.Mode]).
+func (parser *ClientOptionsParser) Mode() error {
+	mode := aerofactory.Mode(parser.aeroURL.GetNetURL().Query().Get("mode"))
+
+	switch mode {
+	case "":
+	case aerofactory.ModeReadWrite, aerofactory.ModeReadOnly:
+		parser.clientFactory.SetMode(mode)
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidMode, mode)
+	}
+
+	return nil
+}
+
+// Parses `verify_namespace` (See: [aerofactory.Preflight]).
+func (parser *ClientOptionsParser) VerifyNamespace() error {
+	verifyNamespaceStr := parser.aeroURL.GetNetURL().Query().Get("verify_namespace")
+	if verifyNamespaceStr == "" {
+		return nil
+	}
+
+	verifyNamespace, err := strconv.ParseBool(verifyNamespaceStr)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidVerifyNamespace, verifyNamespaceStr)
+	}
+
+	parser.preflight().VerifyNamespace = verifyNamespace
+	return nil
+}
+
+// Parses comma-separated `require_roles` (See: [aerofactory.Preflight]).
+func (parser *ClientOptionsParser) RequireRoles() {
+	requireRolesStr := parser.aeroURL.GetNetURL().Query().Get("require_roles")
+
+	for _, role := range strings.Split(requireRolesStr, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			parser.preflight().RequireRoles = append(parser.preflight().RequireRoles, role)
+		}
+	}
+}
+
+// Returns factory preflight checks, setting empty ones first, if factory has none.
+func (parser *ClientOptionsParser) preflight() *aerofactory.Preflight {
+	if parser.clientFactory.GetPreflight() == nil {
+		parser.clientFactory.SetPreflight(&aerofactory.Preflight{})
+	}
+
+	return parser.clientFactory.GetPreflight()
+}
+
+// Parses `warm_up` (connections per node or `min`) & `warm_up_timeout` (See: [aerofactory.WarmUp]).
+func (parser *ClientOptionsParser) WarmUp() error {
+	query := parser.aeroURL.GetNetURL().Query()
+
+	warmUpStr := query.Get("warm_up")
+	if warmUpStr == "" {
+		return nil
+	}
+
+	warmUp := &aerofactory.WarmUp{}
+
+	if warmUpStr == "min" {
+		warmUp.Min = true
+	} else if count, err := strconv.Atoi(warmUpStr); err == nil && count > 0 {
+		warmUp.Count = count
+	} else {
+		return fmt.Errorf("%w: %s", ErrInvalidWarmUp, warmUpStr)
+	}
+
+	if timeoutStr := query.Get("warm_up_timeout"); timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidWarmUpTimeout, timeoutStr)
+		}
+
+		warmUp.Timeout = timeout
+	}
+
+	parser.clientFactory.SetWarmUp(warmUp)
+	return nil
+}
+
+// Parses `log_level` (See: [aerofactory.LogLevel]).
+func (parser *ClientOptionsParser) LogLevel() error {
+	level := aerofactory.LogLevel(parser.aeroURL.GetNetURL().Query().Get("log_level"))
+
+	switch level {
+	case "":
+	case aerofactory.LogLevelDebug, aerofactory.LogLevelInfo, aerofactory.LogLevelWarn, aerofactory.LogLevelError, aerofactory.LogLevelOff:
+		parser.clientFactory.SetLogLevel(level)
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidLogLevel, level)
+	}
+
+	return nil
+}
+
+// Parses `chaos_latency`, `chaos_error_rate`, comma-separated `chaos_errors` & `chaos_seed` (See: [aerofactory.Chaos]).
+// Parsed chaos is applied only if allowed (See: [aerofactory.AerospikeClientFactory.AllowChaos]).
+func (parser *ClientOptionsParser) Chaos() error {
+	query := parser.aeroURL.GetNetURL().Query()
+	if !query.Has("chaos_latency") && !query.Has("chaos_error_rate") && !query.Has("chaos_errors") && !query.Has("chaos_seed") {
+		return nil
+	}
+
+	chaos := &aerofactory.Chaos{}
+
+	if latencyStr := query.Get("chaos_latency"); latencyStr != "" {
+		latency, err := time.ParseDuration(latencyStr)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidChaosLatency, latencyStr)
+		}
+
+		chaos.Latency = latency
+	}
+
+	if errorRateStr := query.Get("chaos_error_rate"); errorRateStr != "" {
+		errorRate, err := strconv.ParseFloat(errorRateStr, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidChaosErrorRate, errorRateStr)
+		}
+
+		chaos.ErrorRate = errorRate
+	}
+
+	for _, faultStr := range strings.Split(query.Get("chaos_errors"), ",") {
+		fault := aerofactory.ChaosFault(strings.TrimSpace(faultStr))
+		if fault == "" {
+			continue
+		}
+
+		chaos.Errors = append(chaos.Errors, fault)
+	}
+
+	if seedStr := query.Get("chaos_seed"); seedStr != "" {
+		seed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidChaosSeed, seedStr)
+		}
+
+		chaos.Seed = seed
+	}
+
+	// Ranges are checked by the same validator as JSON documents
+	if err := chaos.Validate(); err != nil {
+		return err
+	}
+
+	parser.clientFactory.SetChaos(chaos)
+	return nil
+}
+
+// Parses `breaker_failure_ratio`, `breaker_window`, `breaker_cooldown` & `breaker_min_requests` (See: [aerofactory.Breaker]).
+// Circuit breaker is set, if any of them is present; defaults are used for the rest.
+func (parser *ClientOptionsParser) Breaker() error {
+	query := parser.aeroURL.GetNetURL().Query()
+	if !query.Has("breaker_failure_ratio") && !query.Has("breaker_window") && !query.Has("breaker_cooldown") && !query.Has("breaker_min_requests") {
+		return nil
+	}
+
+	breaker := &aerofactory.Breaker{}
+
+	if failureRatioStr := query.Get("breaker_failure_ratio"); failureRatioStr != "" {
+		failureRatio, err := strconv.ParseFloat(failureRatioStr, 64)
+		if err != nil || failureRatio == 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidBreakerFailureRatio, failureRatioStr)
+		}
+
+		breaker.FailureRatio = failureRatio
+	}
+
+	if windowStr := query.Get("breaker_window"); windowStr != "" {
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidBreakerWindow, windowStr)
+		}
+
+		breaker.Window = window
+	}
+
+	if cooldownStr := query.Get("breaker_cooldown"); cooldownStr != "" {
+		cooldown, err := time.ParseDuration(cooldownStr)
+		if err != nil || cooldown <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidBreakerCooldown, cooldownStr)
+		}
+
+		breaker.Cooldown = cooldown
+	}
+
+	if minRequestsStr := query.Get("breaker_min_requests"); minRequestsStr != "" {
+		minRequests, err := strconv.Atoi(minRequestsStr)
+		if err != nil || minRequests <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidBreakerMinRequests, minRequestsStr)
+		}
+
+		breaker.MinRequests = minRequests
+	}
+
+	// Ranges are checked by the same validator as JSON documents
+	if err := breaker.Validate(); err != nil {
+		return err
+	}
+
+	parser.clientFactory.SetBreaker(breaker)
+	return nil
+}
+
+// Parses `rate_limit`, `rate_burst` & `rate_limit_mode`, as well as `rate_limit` & `rate_burst` prefixed with
+// operation class, e.g. `write.rate_limit` (See: [aerofactory.RateLimits]). Rate is a number of operations
+// per `s`, `m`, `h` or any duration, e.g. `5000/s` or `100/10ms`; bare number is per second.
+// Rate limits are set, if any rate limit is present.
+func (parser *ClientOptionsParser) RateLimits() error {
+	query := parser.aeroURL.GetNetURL().Query()
+	rateLimits := &aerofactory.RateLimits{}
+
+	rateLimit, err := parseRateLimit(query.Get("rate_limit"), query.Get("rate_burst"))
+	if err != nil {
+		return err
+	}
+
+	rateLimits.All = rateLimit
+
+	for _, class := range aerofactory.OperationClasses() {
+		prefix := string(class) + "."
+
+		rateLimit, err := parseRateLimit(query.Get(prefix+"rate_limit"), query.Get(prefix+"rate_burst"))
+		if err != nil {
+			return fmt.Errorf("%s: %w", class, err)
+		}
+
+		if rateLimit != nil {
+			if rateLimits.Classes == nil {
+				rateLimits.Classes = map[aerofactory.OperationClass]*aerofactory.RateLimit{}
+			}
+
+			rateLimits.Classes[class] = rateLimit
+		}
+	}
+
+	rateLimits.Mode = aerofactory.RateLimitMode(query.Get("rate_limit_mode"))
+
+	// Ranges & mode are checked by the same validator as JSON documents
+	if err := rateLimits.Validate(); err != nil {
+		return err
+	}
+
+	if rateLimits.All == nil && rateLimits.Classes == nil {
+		return nil
+	}
+
+	parser.clientFactory.SetRateLimits(rateLimits)
+	return nil
+}
+
+// Parses rate limit of rate (e.g. `5000/s`) & burst. Returns nil, if rate & burst are empty.
+func parseRateLimit(rateStr string, burstStr string) (*aerofactory.RateLimit, error) {
+	if rateStr == "" {
+		if burstStr != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRateBurst, burstStr)
+		}
+
+		return nil, nil
+	}
+
+	countStr, perStr, hasPer := strings.Cut(rateStr, "/")
+
+	count, err := strconv.ParseFloat(countStr, 64)
+	if err != nil || !(count > 0) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRateLimit, rateStr)
+	}
+
+	per := time.Second
+	if hasPer {
+		// Bare unit, e.g. `s` in `5000/s`, is a single unit
+		if perStr != "" && (perStr[0] < '0' || perStr[0] > '9') {
+			perStr = "1" + perStr
+		}
+
+		per, err = time.ParseDuration(perStr)
+		if err != nil || per <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRateLimit, rateStr)
+		}
+	}
+
+	rateLimit := &aerofactory.RateLimit{Rate: count / per.Seconds()}
+
+	if burstStr != "" {
+		burst, err := strconv.Atoi(burstStr)
+		if err != nil || burst <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRateBurst, burstStr)
+		}
+
+		rateLimit.Burst = burst
+	}
+
+	return rateLimit, nil
+}
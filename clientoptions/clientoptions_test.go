@@ -0,0 +1,318 @@
+package clientoptions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+func TestClientOptionsParser_ModeDefault(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+	if err := parser.Mode(); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if clientFactory.GetMode() != aerofactory.ModeReadWrite {
+		t.Fatalf("got: %v, want: %v", clientFactory.GetMode(), aerofactory.ModeReadWrite)
+	}
+}
+
+func TestClientOptionsParser_ModeReadOnly(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?mode=readonly")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+	if err := parser.Mode(); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if clientFactory.GetMode() != aerofactory.ModeReadOnly {
+		t.Fatalf("got: %v, want: %v", clientFactory.GetMode(), aerofactory.ModeReadOnly)
+	}
+}
+
+func TestClientOptionsParser_ModeInvalid(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?mode=read-only")
+
+	err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{})
+	if !errors.Is(err, ErrInvalidMode) {
+		t.Fatalf("got: %v, want: error is clientoptions.ErrInvalidMode", err)
+	}
+}
+
+func TestClientOptionsParser_Preflight(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?verify_namespace=true&require_roles=read-write,%20sindex-admin")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	if err := Parse(aeroURL, clientFactory); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	want := &aerofactory.Preflight{VerifyNamespace: true, RequireRoles: []string{"read-write", "sindex-admin"}}
+	if !reflect.DeepEqual(clientFactory.GetPreflight(), want) {
+		t.Fatalf("got: %v, want: %v", clientFactory.GetPreflight(), want)
+	}
+}
+
+func TestClientOptionsParser_PreflightNotSet(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	if err := Parse(aeroURL, clientFactory); err != nil || clientFactory.GetPreflight() != nil {
+		t.Fatalf("got: %v (%v), want: no preflight checks", clientFactory.GetPreflight(), err)
+	}
+}
+
+func TestClientOptionsParser_VerifyNamespaceInvalid(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?verify_namespace=yes")
+
+	err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{})
+	if !errors.Is(err, ErrInvalidVerifyNamespace) {
+		t.Fatalf("got: %v, want: error is clientoptions.ErrInvalidVerifyNamespace", err)
+	}
+}
+
+func TestClientOptionsParser_WarmUp(t *testing.T) {
+	tests := map[string]*aerofactory.WarmUp{
+		"warm_up=16":                      {Count: 16},
+		"warm_up=min&warm_up_timeout=10s": {Min: true, Timeout: 10 * time.Second},
+	}
+
+	for query, want := range tests {
+		aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?" + query)
+		clientFactory := &aerofactory.AerospikeClientFactory{}
+
+		parser := &ClientOptionsParser{aeroURL, clientFactory}
+		if err := parser.WarmUp(); err != nil {
+			t.Fatalf("got: %v, want: error = nil", err)
+		}
+
+		if !reflect.DeepEqual(clientFactory.GetWarmUp(), want) {
+			t.Errorf("got: %v, want: %v", clientFactory.GetWarmUp(), want)
+		}
+	}
+}
+
+func TestClientOptionsParser_WarmUpInvalid(t *testing.T) {
+	tests := map[string]error{
+		"warm_up=0":                        ErrInvalidWarmUp,
+		"warm_up=max":                      ErrInvalidWarmUp,
+		"warm_up=min&warm_up_timeout=soon": ErrInvalidWarmUpTimeout,
+		"warm_up=8&warm_up_timeout=-1s":    ErrInvalidWarmUpTimeout,
+	}
+
+	for query, want := range tests {
+		aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?" + query)
+
+		if err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{}); !errors.Is(err, want) {
+			t.Errorf("got: %v, want: error is %v", err, want)
+		}
+	}
+}
+
+func TestClientOptionsParser_LogLevel(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?log_level=warn")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+	if err := parser.LogLevel(); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if clientFactory.GetLogLevel() != aerofactory.LogLevelWarn {
+		t.Fatalf("got: %v, want: %v", clientFactory.GetLogLevel(), aerofactory.LogLevelWarn)
+	}
+}
+
+func TestClientOptionsParser_LogLevelInvalid(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?log_level=verbose")
+
+	err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{})
+	if !errors.Is(err, ErrInvalidLogLevel) {
+		t.Fatalf("got: %v, want: error is clientoptions.ErrInvalidLogLevel", err)
+	}
+}
+
+func TestClientOptionsParser_Chaos(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?chaos_latency=20ms&chaos_error_rate=0.01&chaos_errors=timeout,key_not_found&chaos_seed=42")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+	if err := parser.Chaos(); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	want := &aerofactory.Chaos{
+		Latency:   20 * time.Millisecond,
+		ErrorRate: 0.01,
+		Errors:    []aerofactory.ChaosFault{aerofactory.ChaosFaultTimeout, aerofactory.ChaosFaultKeyNotFound},
+		Seed:      42,
+	}
+
+	if !reflect.DeepEqual(clientFactory.GetChaos(), want) {
+		t.Errorf("got: %v, want: %v", clientFactory.GetChaos(), want)
+	}
+
+	if clientFactory.ChaosAllowed() {
+		t.Error("got: chaos allowed, want: chaos not allowed by URL")
+	}
+}
+
+func TestClientOptionsParser_ChaosNotSet(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	if err := Parse(aeroURL, clientFactory); err != nil || clientFactory.GetChaos() != nil {
+		t.Errorf("got: %v, %v, want: chaos = nil", clientFactory.GetChaos(), err)
+	}
+}
+
+func TestClientOptionsParser_ChaosInvalid(t *testing.T) {
+	tests := map[string]error{
+		"chaos_latency=-1s":         ErrInvalidChaosLatency,
+		"chaos_latency=slow":        ErrInvalidChaosLatency,
+		"chaos_error_rate=1.5":      ErrInvalidChaosErrorRate,
+		"chaos_error_rate=often":    ErrInvalidChaosErrorRate,
+		"chaos_error_rate=NaN":      ErrInvalidChaosErrorRate,
+		"chaos_errors=timeout,oops": ErrInvalidChaosErrors,
+		"chaos_seed=0x2a":           ErrInvalidChaosSeed,
+	}
+
+	for query, want := range tests {
+		aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?" + query)
+
+		if err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{}); !errors.Is(err, want) {
+			t.Errorf("got: %v, want: error is %v", err, want)
+		}
+	}
+}
+
+func TestClientOptionsParser_Breaker(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?breaker_failure_ratio=0.25&breaker_window=30s&breaker_cooldown=2s&breaker_min_requests=50")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+	if err := parser.Breaker(); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	want := &aerofactory.Breaker{FailureRatio: 0.25, Window: 30 * time.Second, Cooldown: 2 * time.Second, MinRequests: 50}
+	if !reflect.DeepEqual(clientFactory.GetBreaker(), want) {
+		t.Errorf("got: %v, want: %v", clientFactory.GetBreaker(), want)
+	}
+}
+
+func TestClientOptionsParser_BreakerNotSet(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	if err := Parse(aeroURL, clientFactory); err != nil || clientFactory.GetBreaker() != nil {
+		t.Errorf("got: %v, %v, want: breaker = nil", clientFactory.GetBreaker(), err)
+	}
+}
+
+func TestClientOptionsParser_BreakerInvalid(t *testing.T) {
+	tests := map[string]error{
+		"breaker_failure_ratio=0":   ErrInvalidBreakerFailureRatio,
+		"breaker_failure_ratio=1.5": ErrInvalidBreakerFailureRatio,
+		"breaker_failure_ratio=NaN": ErrInvalidBreakerFailureRatio,
+		"breaker_window=0s":         ErrInvalidBreakerWindow,
+		"breaker_window=long":       ErrInvalidBreakerWindow,
+		"breaker_cooldown=-1s":      ErrInvalidBreakerCooldown,
+		"breaker_min_requests=0":    ErrInvalidBreakerMinRequests,
+		"breaker_min_requests=many": ErrInvalidBreakerMinRequests,
+	}
+
+	for query, want := range tests {
+		aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?" + query)
+
+		if err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{}); !errors.Is(err, want) {
+			t.Errorf("got: %v, want: error is %v", err, want)
+		}
+	}
+}
+
+func TestClientOptionsParser_RateLimits(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?rate_limit=5000/s&rate_burst=500&write.rate_limit=60000/m&batch.rate_limit=10&rate_limit_mode=fail_fast")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	parser := &ClientOptionsParser{aeroURL, clientFactory}
+	if err := parser.RateLimits(); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	want := &aerofactory.RateLimits{
+		All: &aerofactory.RateLimit{Rate: 5000, Burst: 500},
+		Classes: map[aerofactory.OperationClass]*aerofactory.RateLimit{
+			aerofactory.OperationWrite: {Rate: 1000},
+			aerofactory.OperationBatch: {Rate: 10},
+		},
+		Mode: aerofactory.RateLimitFailFast,
+	}
+
+	if !reflect.DeepEqual(clientFactory.GetRateLimits(), want) {
+		t.Errorf("got: %+v, want: %+v", clientFactory.GetRateLimits(), want)
+	}
+}
+
+func TestClientOptionsParser_RateLimitsPer(t *testing.T) {
+	tests := map[string]float64{
+		"200":      200,
+		"200/s":    200,
+		"30/m":     0.5,
+		"7200/h":   2,
+		"100/10ms": 10000,
+	}
+
+	for rateStr, want := range tests {
+		aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?read.rate_limit=" + rateStr)
+		clientFactory := &aerofactory.AerospikeClientFactory{}
+
+		if err := Parse(aeroURL, clientFactory); err != nil {
+			t.Fatalf("got: %v, want: error = nil", err)
+		}
+
+		if got := clientFactory.GetRateLimits().Classes[aerofactory.OperationRead].Rate; got != want {
+			t.Errorf("got: %v, want: %v for %s", got, want, rateStr)
+		}
+	}
+}
+
+func TestClientOptionsParser_RateLimitsNotSet(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?rate_limit_mode=wait")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+
+	if err := Parse(aeroURL, clientFactory); err != nil || clientFactory.GetRateLimits() != nil {
+		t.Errorf("got: %v, %v, want: rate limits = nil", clientFactory.GetRateLimits(), err)
+	}
+}
+
+func TestClientOptionsParser_RateLimitsInvalid(t *testing.T) {
+	tests := map[string]error{
+		"rate_limit=0/s":                         ErrInvalidRateLimit,
+		"rate_limit=fast":                        ErrInvalidRateLimit,
+		"rate_limit=5000/day":                    ErrInvalidRateLimit,
+		"write.rate_limit=-1/s":                  ErrInvalidRateLimit,
+		"rate_limit=NaN/s":                       ErrInvalidRateLimit,
+		"rate_limit=Inf":                         ErrInvalidRateLimit,
+		"rate_limit=5000/s&rate_burst=0":         ErrInvalidRateBurst,
+		"rate_burst=500":                         ErrInvalidRateBurst,
+		"read.rate_burst=500":                    ErrInvalidRateBurst,
+		"rate_limit=5000/s&rate_limit_mode=drop": ErrInvalidRateLimitMode,
+	}
+
+	for query, want := range tests {
+		aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?" + query)
+
+		if err := Parse(aeroURL, &aerofactory.AerospikeClientFactory{}); !errors.Is(err, want) {
+			t.Errorf("got: %v, want: error is %v", err, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Clientoptions package.
+// Client options beyond [aerospike.ClientPolicy] (mode, preflight checks, warm-up, ...) are parsed from [aerourl.AerospikeURL] here.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+package clientoptions
+
+import (
+	"errors"
+
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+var (
+	// `mode` URL query parameter is not one of supported modes
+	ErrInvalidMode = errors.New("invalid mode, want: readwrite or readonly")
+
+	// `verify_namespace` URL query parameter is not a boolean
+	ErrInvalidVerifyNamespace = errors.New("invalid verify_namespace, want: true or false")
+
+	// `warm_up` URL query parameter is neither a positive integer nor `min`
+	ErrInvalidWarmUp = errors.New("invalid warm_up, want: positive integer or min")
+
+	// `warm_up_timeout` URL query parameter is not a positive duration
+	ErrInvalidWarmUpTimeout = errors.New("invalid warm_up_timeout, want: positive duration, e.g. 5s")
+
+	// `log_level` URL query parameter is not one of supported levels
+	ErrInvalidLogLevel = errors.New("invalid log_level, want: debug, info, warn, error or off")
+
+	// `chaos_latency` URL query parameter is not a non-negative duration (See: [aerofactory.ErrInvalidChaosLatency])
+	ErrInvalidChaosLatency = aerofactory.ErrInvalidChaosLatency
+
+	// `chaos_error_rate` URL query parameter is not a number from 0 to 1 (See: [aerofactory.ErrInvalidChaosErrorRate])
+	ErrInvalidChaosErrorRate = aerofactory.ErrInvalidChaosErrorRate
+
+	// `chaos_errors` URL query parameter contains unknown fault (See: [aerofactory.ErrInvalidChaosErrors])
+	ErrInvalidChaosErrors = aerofactory.ErrInvalidChaosErrors
+
+	// `chaos_seed` URL query parameter is not an integer
+	ErrInvalidChaosSeed = errors.New("invalid chaos_seed, want: integer")
+
+	// `breaker_failure_ratio` URL query parameter is not a number above 0 up to 1 (See: [aerofactory.ErrInvalidBreakerFailureRatio])
+	ErrInvalidBreakerFailureRatio = aerofactory.ErrInvalidBreakerFailureRatio
+
+	// `breaker_window` URL query parameter is not a positive duration (See: [aerofactory.ErrInvalidBreakerWindow])
+	ErrInvalidBreakerWindow = aerofactory.ErrInvalidBreakerWindow
+
+	// `breaker_cooldown` URL query parameter is not a positive duration (See: [aerofactory.ErrInvalidBreakerCooldown])
+	ErrInvalidBreakerCooldown = aerofactory.ErrInvalidBreakerCooldown
+
+	// `breaker_min_requests` URL query parameter is not a positive integer (See: [aerofactory.ErrInvalidBreakerMinRequests])
+	ErrInvalidBreakerMinRequests = aerofactory.ErrInvalidBreakerMinRequests
+
+	// `rate_limit` URL query parameter (or its class-prefixed variant, e.g. `write.rate_limit`) is not a positive rate (See: [aerofactory.ErrInvalidRateLimit])
+	ErrInvalidRateLimit = aerofactory.ErrInvalidRateLimit
+
+	// `rate_burst` URL query parameter (or its class-prefixed variant) is not a positive integer or is set without rate limit (See: [aerofactory.ErrInvalidRateBurst])
+	ErrInvalidRateBurst = aerofactory.ErrInvalidRateBurst
+
+	// `rate_limit_mode` URL query parameter is not one of supported modes (See: [aerofactory.ErrInvalidRateLimitMode])
+	ErrInvalidRateLimitMode = aerofactory.ErrInvalidRateLimitMode
+)
@@ -0,0 +1,34 @@
+package clientoptions
+
+// Returns URL query parameters of client options, including ones parsed outside of [clientoptions.Parse]
+// (`failover*` parameters are parsed by failover package).
+func Params() []string {
+	return []string{
+		"mode",
+		"verify_namespace",
+		"require_roles",
+		"warm_up",
+		"warm_up_timeout",
+		"log_level",
+		"failover",
+		"failover_threshold",
+		"failover_probe_interval",
+		"chaos_latency",
+		"chaos_error_rate",
+		"chaos_errors",
+		"chaos_seed",
+		"breaker_failure_ratio",
+		"breaker_window",
+		"breaker_cooldown",
+		"breaker_min_requests",
+		"rate_limit",
+		"rate_burst",
+		"rate_limit_mode",
+		"read.rate_limit",
+		"read.rate_burst",
+		"write.rate_limit",
+		"write.rate_burst",
+		"batch.rate_limit",
+		"batch.rate_burst",
+	}
+}
@@ -0,0 +1,15 @@
+package clientoptions
+
+import "testing"
+
+func TestParamsUnique(t *testing.T) {
+	seen := map[string]bool{}
+
+	for _, param := range Params() {
+		if seen[param] {
+			t.Errorf("got: %v twice, want: unique params", param)
+		}
+
+		seen[param] = true
+	}
+}
@@ -0,0 +1,105 @@
+package clientpolicy
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+// Encodes [aerospike.ClientPolicy] into URL query parameters understood by [clientpolicy.Parse].
+// Only values differing from [aerospike.NewClientPolicy] defaults are encoded,
+// so equivalent policies are always encoded the same way.
+// `User` & `Password` are not encoded, as they belong to URL user info.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+// [aerospike.NewClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#NewClientPolicy
+func Encode(policy *aerospike.ClientPolicy) url.Values {
+	query := url.Values{}
+	if policy == nil {
+		return query
+	}
+
+	defaults := aerospike.NewClientPolicy()
+
+	if policy.AuthMode != defaults.AuthMode {
+		switch policy.AuthMode {
+		case aerospike.AuthModeExternal:
+			query.Set("auth_mode", "auth_mode_external")
+		case aerospike.AuthModePKI:
+			query.Set("auth_mode", "auth_mode_pki")
+		default:
+			query.Set("auth_mode", "auth_mode_internal")
+		}
+	}
+
+	if policy.ClusterName != defaults.ClusterName {
+		query.Set("cluster_name", policy.ClusterName)
+	}
+
+	if policy.Timeout != defaults.Timeout {
+		query.Set("timeout", policy.Timeout.String())
+	}
+
+	if policy.IdleTimeout != defaults.IdleTimeout {
+		query.Set("idle_timeout", policy.IdleTimeout.String())
+	}
+
+	if policy.LoginTimeout != defaults.LoginTimeout {
+		query.Set("login_timeout", policy.LoginTimeout.String())
+	}
+
+	if policy.ConnectionQueueSize != defaults.ConnectionQueueSize {
+		query.Set("connection_queue_size", strconv.Itoa(policy.ConnectionQueueSize))
+	}
+
+	if policy.MinConnectionsPerNode != defaults.MinConnectionsPerNode {
+		query.Set("min_connections_per_node", strconv.Itoa(policy.MinConnectionsPerNode))
+	}
+
+	if policy.MaxErrorRate != defaults.MaxErrorRate {
+		query.Set("max_error_rate", strconv.Itoa(policy.MaxErrorRate))
+	}
+
+	if policy.ErrorRateWindow != defaults.ErrorRateWindow {
+		query.Set("error_rate_window", strconv.Itoa(policy.ErrorRateWindow))
+	}
+
+	if policy.LimitConnectionsToQueueSize != defaults.LimitConnectionsToQueueSize {
+		query.Set("limit_connections_to_queue_size", strconv.FormatBool(policy.LimitConnectionsToQueueSize))
+	}
+
+	if policy.OpeningConnectionThreshold != defaults.OpeningConnectionThreshold {
+		query.Set("opening_connection_threshold", strconv.Itoa(policy.OpeningConnectionThreshold))
+	}
+
+	if policy.FailIfNotConnected != defaults.FailIfNotConnected {
+		query.Set("fail_if_not_connected", strconv.FormatBool(policy.FailIfNotConnected))
+	}
+
+	if policy.TendInterval != defaults.TendInterval {
+		query.Set("tend_interval", policy.TendInterval.String())
+	}
+
+	if policy.UseServicesAlternate != defaults.UseServicesAlternate {
+		query.Set("use_services_alternate", strconv.FormatBool(policy.UseServicesAlternate))
+	}
+
+	if policy.RackAware != defaults.RackAware {
+		query.Set("rack_aware", strconv.FormatBool(policy.RackAware))
+	}
+
+	if policy.RackId != defaults.RackId {
+		query.Set("rack_id", strconv.Itoa(policy.RackId))
+	}
+
+	if policy.IgnoreOtherSubnetAliases != defaults.IgnoreOtherSubnetAliases {
+		query.Set("ignore_subnet_aliases", strconv.FormatBool(policy.IgnoreOtherSubnetAliases))
+	}
+
+	if policy.SeedOnlyCluster != defaults.SeedOnlyCluster {
+		query.Set("seed_only_cluster", strconv.FormatBool(policy.SeedOnlyCluster))
+	}
+
+	return query
+}
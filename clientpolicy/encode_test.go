@@ -0,0 +1,58 @@
+package clientpolicy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+func TestEncodeDefaults(t *testing.T) {
+	query := Encode(aerospike.NewClientPolicy())
+
+	if len(query) != 0 {
+		t.Errorf("got: %v, want: empty query", query)
+	}
+}
+
+func TestEncodeNil(t *testing.T) {
+	query := Encode(nil)
+
+	if len(query) != 0 {
+		t.Errorf("got: %v, want: empty query", query)
+	}
+}
+
+func TestEncodeParseRoundTrip(t *testing.T) {
+	policy := aerospike.NewClientPolicy()
+	policy.AuthMode = aerospike.AuthModeExternal
+	policy.ClusterName = "aero-cluster-001"
+	policy.Timeout = 10 * time.Second
+	policy.IdleTimeout = 3 * time.Second
+	policy.MaxErrorRate = 50
+	policy.FailIfNotConnected = false
+	policy.RackAware = true
+	policy.RackId = 2
+
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?" + Encode(policy).Encode())
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+	Parse(aeroURL, clientFactory)
+
+	if !reflect.DeepEqual(clientFactory.GetClientPolicy(), policy) {
+		t.Errorf("got: %v, want: %v", clientFactory.GetClientPolicy(), policy)
+	}
+}
+
+func TestEncodeSkipsDefaultValues(t *testing.T) {
+	aeroURL, _ := aerourl.Init("aerospike://127.0.0.1:3000/aero-namespace-001?timeout=30s&max_error_rate=50")
+	clientFactory := &aerofactory.AerospikeClientFactory{}
+	Parse(aeroURL, clientFactory)
+
+	query := Encode(clientFactory.GetClientPolicy())
+	if query.Encode() != "max_error_rate=50" {
+		t.Errorf("got: %v, want: max_error_rate=50", query.Encode())
+	}
+}
@@ -0,0 +1,39 @@
+package clientpolicy
+
+// Describes URL query parameter and [aerospike.ClientPolicy] field it is parsed into.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+type Param struct {
+	// URL query parameter name, e.g. `idle_timeout`
+	Name string
+
+	// [aerospike.ClientPolicy] field name, e.g. `IdleTimeout`
+	//
+	// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+	Field string
+}
+
+// Returns URL query parameters supported by [clientpolicy.Parse] in parsing order.
+// `User` & `Password` are not listed, as they are parsed from URL user info.
+func Params() []Param {
+	return []Param{
+		{"auth_mode", "AuthMode"},
+		{"cluster_name", "ClusterName"},
+		{"timeout", "Timeout"},
+		{"idle_timeout", "IdleTimeout"},
+		{"login_timeout", "LoginTimeout"},
+		{"connection_queue_size", "ConnectionQueueSize"},
+		{"min_connections_per_node", "MinConnectionsPerNode"},
+		{"max_error_rate", "MaxErrorRate"},
+		{"error_rate_window", "ErrorRateWindow"},
+		{"limit_connections_to_queue_size", "LimitConnectionsToQueueSize"},
+		{"opening_connection_threshold", "OpeningConnectionThreshold"},
+		{"fail_if_not_connected", "FailIfNotConnected"},
+		{"tend_interval", "TendInterval"},
+		{"use_services_alternate", "UseServicesAlternate"},
+		{"rack_aware", "RackAware"},
+		{"rack_id", "RackId"},
+		{"ignore_subnet_aliases", "IgnoreOtherSubnetAliases"},
+		{"seed_only_cluster", "SeedOnlyCluster"},
+	}
+}
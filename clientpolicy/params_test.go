@@ -0,0 +1,18 @@
+package clientpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+)
+
+func TestParamsFieldsExist(t *testing.T) {
+	policyType := reflect.TypeOf(aerospike.ClientPolicy{})
+
+	for _, param := range Params() {
+		if _, ok := policyType.FieldByName(param.Field); !ok {
+			t.Errorf("got: %v, want: aerospike.ClientPolicy field for param %v", param.Field, param.Name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Root package
+package aerospikeurl
+
+import (
+	"encoding/json"
+
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+// Serves as Aerospike connection string, validated at decode time.
+// Implements [flag.Value], [encoding.TextUnmarshaler], [encoding.TextMarshaler] & [json.Unmarshaler],
+// so it can be used directly as a CLI flag or a config struct field instead of a string & manual [Parse] call.
+//
+// Connection string is marshalled back with password redacted.
+//
+//	var connStr aerospikeurl.ConnString
+//	flag.Var(&connStr, "aerospike-url", "Aerospike connection string")
+type ConnString struct {
+	aeroURL       *aerourl.AerospikeURL
+	clientFactory *aerofactory.AerospikeClientFactory
+}
+
+// Parses & validates connection string. Implements [flag.Value].
+// Returns error, if connection string is invalid (See: [Parse]).
+func (connStr *ConnString) Set(value string) error {
+	aeroURL, err := aerourl.Init(value)
+	if err != nil {
+		return err
+	}
+
+	clientFactory, err := generateClientFactory(aeroURL)
+	if err != nil {
+		return err
+	}
+
+	connStr.aeroURL = aeroURL
+	connStr.clientFactory = clientFactory
+
+	return nil
+}
+
+// Returns connection string with passwords redacted, including those of standby clusters in `failover` parameters.
+// Implements [flag.Value]. Returns empty string, if connection string was not set.
+func (connStr ConnString) String() string {
+	if connStr.aeroURL == nil {
+		return ""
+	}
+
+	return connStr.aeroURL.Redacted()
+}
+
+// Parses & validates connection string. Implements [encoding.TextUnmarshaler].
+func (connStr *ConnString) UnmarshalText(text []byte) error {
+	return connStr.Set(string(text))
+}
+
+// Returns connection string with password redacted. Implements [encoding.TextMarshaler].
+func (connStr ConnString) MarshalText() ([]byte, error) {
+	return []byte(connStr.String()), nil
+}
+
+// Parses & validates connection string from JSON string. Implements [json.Unmarshaler].
+// JSON null leaves connection string unset.
+func (connStr *ConnString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	return connStr.Set(value)
+}
+
+// Returns validated [aerourl.AerospikeURL] or nil, if connection string was not set.
+func (connStr ConnString) AerospikeURL() *aerourl.AerospikeURL {
+	return connStr.aeroURL
+}
+
+// Returns [aerofactory.AerospikeClientFactory] parsed from connection string or nil, if connection string was not set.
+func (connStr ConnString) Factory() *aerofactory.AerospikeClientFactory {
+	return connStr.clientFactory
+}
@@ -0,0 +1,24 @@
+// Root package
+package aerospikeurl
+
+import "errors"
+
+var (
+	// Layered configuration was resolved without any Aerospike DB host
+	ErrNoHosts = errors.New("aerospike hosts cannot be empty")
+
+	// Aerospike DB host is not in `hostname:port` format
+	ErrInvalidHost = errors.New("aerospike host is invalid, want: hostname:port")
+
+	// Configuration file contains a key that does not match any known parameter
+	ErrUnknownConfigKey = errors.New("unknown aerospike config key")
+
+	// Connection name is already registered in [Registry]
+	ErrConnectionExists = errors.New("aerospike connection is already registered")
+
+	// Connection name is not registered in [Registry]
+	ErrConnectionNotFound = errors.New("aerospike connection is not registered")
+
+	// [Reloader] was closed & cannot reload anymore
+	ErrReloaderClosed = errors.New("aerospike reloader is closed")
+)
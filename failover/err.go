@@ -0,0 +1,16 @@
+// Failover package.
+// Reads & writes are routed to primary Aerospike cluster & switched to standby ones on connectivity failures here.
+package failover
+
+import "errors"
+
+var (
+	// Failover client was created without standby cluster
+	ErrNoStandby = errors.New("aerospike failover requires at least one standby cluster, want: failover=aerospike://...")
+
+	// `failover_threshold` URL query parameter is not a positive integer
+	ErrInvalidThreshold = errors.New("invalid failover_threshold, want: positive integer")
+
+	// `failover_probe_interval` URL query parameter is not a positive duration
+	ErrInvalidProbeInterval = errors.New("invalid failover_probe_interval, want: positive duration, e.g. 5s")
+)
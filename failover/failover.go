@@ -0,0 +1,323 @@
+// Failover package.
+// Reads & writes are routed to primary Aerospike cluster & switched to standby ones on connectivity failures here.
+package failover
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	aerospikeurl "github.com/tiptophelmet/aerospike-url"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+const (
+	// Default number of consecutive connectivity failures before switching to the next cluster
+	DefaultThreshold = 3
+
+	// Default interval primary cluster health is probed at, while a standby one is active
+	DefaultProbeInterval = 5 * time.Second
+)
+
+// Result codes treated as connectivity failures of a cluster.
+var connectivityResultCodes = []types.ResultCode{
+	types.NETWORK_ERROR,
+	types.TIMEOUT,
+	types.NO_RESPONSE,
+	types.INVALID_NODE_ERROR,
+	types.SERVER_NOT_AVAILABLE,
+	types.NO_AVAILABLE_CONNECTIONS_TO_NODE,
+	types.MAX_RETRIES_EXCEEDED,
+}
+
+// Connection to a single cluster. Satisfied by [aerofactory.LazyClient].
+type cluster interface {
+	Client() (*aerospike.Client, aerospike.Error)
+	Ready() bool
+	Close()
+}
+
+// Configures switching between clusters.
+type Config struct {
+	// Consecutive connectivity failures of active cluster before switching to the next one ([DefaultThreshold], if 0)
+	Threshold int
+
+	// Interval primary cluster health is probed at, while a standby one is active ([DefaultProbeInterval], if 0)
+	ProbeInterval time.Duration
+}
+
+// Serves as a client routing operations to primary cluster & switching to the next standby one
+// after [Config.Threshold] consecutive connectivity failures. While a standby cluster is active,
+// primary one is probed every [Config.ProbeInterval] & switched back to, once it is healthy.
+// Clusters are connected lazily (See: [aerofactory.LazyClient]), so a cluster being down at startup is not fatal.
+type Client struct {
+	clusters []cluster
+	config   Config
+
+	mu       sync.Mutex
+	active   int
+	failures int
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// Parses primary connection string with standby ones in `failover` URL query parameters
+// (URL-encoded, may be repeated) & creates failover [Client]. Switching is configured with
+// `failover_threshold` & `failover_probe_interval` parameters.
+//
+//	aerospike://10.0.0.1:3000/ns?failover=aerospike%3A%2F%2F10.1.0.1%3A3000%2Fns&failover_threshold=3&failover_probe_interval=5s
+func Parse(connStr string) (*Client, error) {
+	primary, err := aerospikeurl.Parse(connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	connURL, _ := url.Parse(connStr)
+	query := connURL.Query()
+
+	factories := []*aerofactory.AerospikeClientFactory{primary}
+	for _, standbyConnStr := range query["failover"] {
+		standby, err := aerospikeurl.Parse(standbyConnStr)
+		if err != nil {
+			return nil, fmt.Errorf("failover: %w", err)
+		}
+
+		factories = append(factories, standby)
+	}
+
+	config := Config{}
+
+	if thresholdStr := query.Get("failover_threshold"); thresholdStr != "" {
+		threshold, err := strconv.Atoi(thresholdStr)
+		if err != nil || threshold <= 0 {
+			return nil, ErrInvalidThreshold
+		}
+
+		config.Threshold = threshold
+	}
+
+	if probeIntervalStr := query.Get("failover_probe_interval"); probeIntervalStr != "" {
+		probeInterval, err := time.ParseDuration(probeIntervalStr)
+		if err != nil || probeInterval <= 0 {
+			return nil, ErrInvalidProbeInterval
+		}
+
+		config.ProbeInterval = probeInterval
+	}
+
+	return NewClient(config, factories...)
+}
+
+// Creates failover [Client] from primary cluster factory followed by standby ones.
+// Returns error, if no standby cluster is given.
+func NewClient(config Config, factories ...*aerofactory.AerospikeClientFactory) (*Client, error) {
+	clusters := make([]cluster, 0, len(factories))
+	for _, clientFactory := range factories {
+		clusters = append(clusters, clientFactory.BuildLazyClient())
+	}
+
+	return newClient(config, clusters)
+}
+
+// Creates failover [Client] from clusters & starts probing primary one.
+func newClient(config Config, clusters []cluster) (*Client, error) {
+	if len(clusters) < 2 {
+		return nil, ErrNoStandby
+	}
+
+	if config.Threshold <= 0 {
+		config.Threshold = DefaultThreshold
+	}
+
+	if config.ProbeInterval <= 0 {
+		config.ProbeInterval = DefaultProbeInterval
+	}
+
+	client := &Client{clusters: clusters, config: config, stop: make(chan struct{})}
+
+	client.wg.Add(1)
+	go client.probePrimary()
+
+	return client, nil
+}
+
+// Runs operation against active cluster & records its outcome.
+// Connectivity failures (network errors, timeouts, no available nodes) count towards [Config.Threshold].
+func (client *Client) Do(operation func(*aerospike.Client) aerospike.Error) aerospike.Error {
+	client.mu.Lock()
+	active := client.active
+	client.mu.Unlock()
+
+	aeroClient, err := client.clusters[active].Client()
+	if err == nil {
+		err = operation(aeroClient)
+	}
+
+	client.record(active, err)
+	return err
+}
+
+// Returns index of active cluster: 0 for primary, 1+ for standby ones in order they were given.
+func (client *Client) Active() int {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	return client.active
+}
+
+// Returns [aerospike.Client] of active cluster, connecting it if needed.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (client *Client) Current() (*aerospike.Client, aerospike.Error) {
+	return client.clusters[client.Active()].Client()
+}
+
+// Reads record from active cluster (See: [aerospike.Client.Get]).
+//
+// [aerospike.Client.Get]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Get
+func (client *Client) Get(policy *aerospike.BasePolicy, key *aerospike.Key, binNames ...string) (record *aerospike.Record, err aerospike.Error) {
+	err = client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		record, err = aeroClient.Get(policy, key, binNames...)
+		return err
+	})
+
+	return record, err
+}
+
+// Writes record to active cluster (See: [aerospike.Client.Put]).
+//
+// [aerospike.Client.Put]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Put
+func (client *Client) Put(policy *aerospike.WritePolicy, key *aerospike.Key, binMap aerospike.BinMap) aerospike.Error {
+	return client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		return aeroClient.Put(policy, key, binMap)
+	})
+}
+
+// Deletes record from active cluster (See: [aerospike.Client.Delete]).
+//
+// [aerospike.Client.Delete]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Delete
+func (client *Client) Delete(policy *aerospike.WritePolicy, key *aerospike.Key) (existed bool, err aerospike.Error) {
+	err = client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		existed, err = aeroClient.Delete(policy, key)
+		return err
+	})
+
+	return existed, err
+}
+
+// Checks record existence in active cluster (See: [aerospike.Client.Exists]).
+//
+// [aerospike.Client.Exists]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Exists
+func (client *Client) Exists(policy *aerospike.BasePolicy, key *aerospike.Key) (exists bool, err aerospike.Error) {
+	err = client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		exists, err = aeroClient.Exists(policy, key)
+		return err
+	})
+
+	return exists, err
+}
+
+// Performs operations on record in active cluster (See: [aerospike.Client.Operate]).
+//
+// [aerospike.Client.Operate]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Operate
+func (client *Client) Operate(policy *aerospike.WritePolicy, key *aerospike.Key, operations ...*aerospike.Operation) (record *aerospike.Record, err aerospike.Error) {
+	err = client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		record, err = aeroClient.Operate(policy, key, operations...)
+		return err
+	})
+
+	return record, err
+}
+
+// Reads records from active cluster (See: [aerospike.Client.BatchGet]).
+//
+// [aerospike.Client.BatchGet]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.BatchGet
+func (client *Client) BatchGet(policy *aerospike.BatchPolicy, keys []*aerospike.Key, binNames ...string) (records []*aerospike.Record, err aerospike.Error) {
+	err = client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		records, err = aeroClient.BatchGet(policy, keys, binNames...)
+		return err
+	})
+
+	return records, err
+}
+
+// Queries active cluster (See: [aerospike.Client.Query]).
+//
+// [aerospike.Client.Query]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Query
+func (client *Client) Query(policy *aerospike.QueryPolicy, statement *aerospike.Statement) (recordset *aerospike.Recordset, err aerospike.Error) {
+	err = client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		recordset, err = aeroClient.Query(policy, statement)
+		return err
+	})
+
+	return recordset, err
+}
+
+// Stops probing primary cluster & closes clients of all clusters.
+func (client *Client) Close() {
+	client.stopOnce.Do(func() {
+		close(client.stop)
+		client.wg.Wait()
+
+		for _, cluster := range client.clusters {
+			cluster.Close()
+		}
+	})
+}
+
+// Records operation outcome on cluster & switches to the next cluster, once threshold is reached.
+// Outcomes of a cluster that is no longer active are ignored.
+func (client *Client) record(clusterIdx int, err aerospike.Error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if clusterIdx != client.active {
+		return
+	}
+
+	if err == nil || !err.Matches(connectivityResultCodes...) {
+		client.failures = 0
+		return
+	}
+
+	client.failures++
+	if client.failures >= client.config.Threshold {
+		client.active = (client.active + 1) % len(client.clusters)
+		client.failures = 0
+	}
+}
+
+// Probes primary cluster every probe interval, while a standby one is active,
+// & switches back to primary one, once it is connected.
+func (client *Client) probePrimary() {
+	defer client.wg.Done()
+
+	ticker := time.NewTicker(client.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-client.stop:
+			return
+		case <-ticker.C:
+			if client.Active() == 0 {
+				continue
+			}
+
+			primary := client.clusters[0]
+			if _, err := primary.Client(); err != nil || !primary.Ready() {
+				continue
+			}
+
+			client.mu.Lock()
+			client.active, client.failures = 0, 0
+			client.mu.Unlock()
+		}
+	}
+}
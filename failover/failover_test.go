@@ -0,0 +1,157 @@
+package failover
+
+import (
+	"errors"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+// Fake cluster with switchable health.
+type fakeCluster struct {
+	client  *aerospike.Client
+	healthy atomic.Bool
+	closed  atomic.Bool
+}
+
+func newFakeCluster(healthy bool) *fakeCluster {
+	cluster := &fakeCluster{client: &aerospike.Client{}}
+	cluster.healthy.Store(healthy)
+
+	return cluster
+}
+
+func (cluster *fakeCluster) Client() (*aerospike.Client, aerospike.Error) {
+	if !cluster.healthy.Load() {
+		return nil, aerospike.ErrNetTimeout
+	}
+
+	return cluster.client, nil
+}
+
+func (cluster *fakeCluster) Ready() bool {
+	return cluster.healthy.Load()
+}
+
+func (cluster *fakeCluster) Close() {
+	cluster.closed.Store(true)
+}
+
+func TestParse(t *testing.T) {
+	standby := url.QueryEscape("aerospike://10.1.0.1:3000/aero-namespace-001")
+	client, err := Parse("aerospike://10.0.0.1:3000/aero-namespace-001?failover=" + standby + "&failover_threshold=5&failover_probe_interval=1s")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+	defer client.Close()
+
+	if len(client.clusters) != 2 || client.config.Threshold != 5 || client.config.ProbeInterval != time.Second {
+		t.Errorf("got: %v clusters, %+v, want: 2 clusters, threshold 5, probe interval 1s", len(client.clusters), client.config)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	standby := url.QueryEscape("aerospike://10.1.0.1:3000/aero-namespace-001")
+
+	tests := map[string]error{
+		"aerospike://10.0.0.1:3000/aero-namespace-001":                                                         ErrNoStandby,
+		"aerospike://10.0.0.1:3000/aero-namespace-001?failover=" + url.QueryEscape("https://10.1.0.1:3000/ns"): aerourl.ErrInvalidScheme,
+		"aerospike://10.0.0.1:3000/aero-namespace-001?failover=" + standby + "&failover_threshold=0":            ErrInvalidThreshold,
+		"aerospike://10.0.0.1:3000/aero-namespace-001?failover=" + standby + "&failover_probe_interval=soon":    ErrInvalidProbeInterval,
+	}
+
+	for connStr, want := range tests {
+		if _, err := Parse(connStr); !errors.Is(err, want) {
+			t.Errorf("got: %v, want: error is %v", err, want)
+		}
+	}
+}
+
+func TestSwitchToStandbyAfterThreshold(t *testing.T) {
+	primary, standby := newFakeCluster(true), newFakeCluster(true)
+
+	client, _ := newClient(Config{Threshold: 2, ProbeInterval: time.Hour}, []cluster{primary, standby})
+	defer client.Close()
+
+	failing := func(*aerospike.Client) aerospike.Error { return aerospike.ErrNetTimeout }
+	notFound := func(*aerospike.Client) aerospike.Error { return aerospike.ErrKeyNotFound }
+
+	client.Do(failing)
+	client.Do(notFound) // not a connectivity failure, resets counter
+	client.Do(failing)
+
+	if client.Active() != 0 {
+		t.Fatalf("got: active = %v, want: 0", client.Active())
+	}
+
+	client.Do(failing)
+	if client.Active() != 1 {
+		t.Fatalf("got: active = %v, want: 1", client.Active())
+	}
+
+	var usedClient *aerospike.Client
+	client.Do(func(aeroClient *aerospike.Client) aerospike.Error {
+		usedClient = aeroClient
+		return nil
+	})
+
+	if usedClient != standby.client {
+		t.Errorf("got: %p, want: standby client %p", usedClient, standby.client)
+	}
+}
+
+func TestSwitchToStandbyWhenPrimaryCannotConnect(t *testing.T) {
+	primary, standby := newFakeCluster(false), newFakeCluster(true)
+
+	client, _ := newClient(Config{Threshold: 1, ProbeInterval: time.Hour}, []cluster{primary, standby})
+	defer client.Close()
+
+	err := client.Do(func(*aerospike.Client) aerospike.Error { return nil })
+	if err == nil || !err.Matches(types.TIMEOUT) {
+		t.Errorf("got: %v, want: timeout", err)
+	}
+
+	if client.Active() != 1 {
+		t.Errorf("got: active = %v, want: 1", client.Active())
+	}
+}
+
+func TestSwitchBackToHealthyPrimary(t *testing.T) {
+	primary, standby := newFakeCluster(false), newFakeCluster(true)
+
+	client, _ := newClient(Config{Threshold: 1, ProbeInterval: time.Millisecond}, []cluster{primary, standby})
+	defer client.Close()
+
+	client.Do(func(*aerospike.Client) aerospike.Error { return nil })
+	if client.Active() != 1 {
+		t.Fatalf("got: active = %v, want: 1", client.Active())
+	}
+
+	primary.healthy.Store(true)
+
+	deadline := time.Now().Add(time.Second)
+	for client.Active() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("got: standby active, want: switched back to primary")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestClose(t *testing.T) {
+	primary, standby := newFakeCluster(true), newFakeCluster(true)
+
+	client, _ := newClient(Config{}, []cluster{primary, standby})
+	client.Close()
+	client.Close()
+
+	if !primary.closed.Load() || !standby.closed.Load() {
+		t.Error("got: clusters open, want: all clusters closed")
+	}
+}
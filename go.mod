@@ -1,6 +1,6 @@
 module github.com/tiptophelmet/aerospike-url
 
-go 1.20
+go 1.21
 
 require github.com/aerospike/aerospike-client-go/v6 v6.13.0
 
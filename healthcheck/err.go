@@ -0,0 +1,14 @@
+// Healthcheck package.
+// HTTP health-check handlers (liveness & readiness) for clients built by [aerofactory.AerospikeClientFactory]
+// or [aerospikeurl.Registry] are served here.
+package healthcheck
+
+import "errors"
+
+var (
+	// Client passed to [Liveness] or [Readiness] is nil
+	ErrNilClient = errors.New("aerospike client is not built")
+
+	// Client of connection registered in [aerospikeurl.Registry] was not built yet
+	ErrNotBuilt = errors.New("aerospike client is not built yet")
+)
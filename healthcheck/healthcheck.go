@@ -0,0 +1,308 @@
+// Healthcheck package.
+// HTTP health-check handlers (liveness & readiness) for clients built by [aerofactory.AerospikeClientFactory]
+// or [aerospikeurl.Registry] are served here.
+//
+// Reports are JSON documents with connection state, node count, per-node status, optional namespace read probe
+// & optional circuit breaker state (See: [Handler.WithBreaker]):
+//
+//	{"status": "up", "clients": [{"name": "default", "connected": true, "nodes": 1, "node_status": [...], "probe": {...}, "breaker": "closed"}]}
+//
+// Reports contain names, node addresses & error messages only, so credentials never leak into them.
+package healthcheck
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	aerospikeurl "github.com/tiptophelmet/aerospike-url"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+const (
+	// Report status of healthy clients
+	StatusUp = "up"
+
+	// Report status of unhealthy clients
+	StatusDown = "down"
+)
+
+const (
+	// Name clients passed directly (not via registry) are reported under
+	DefaultName = "default"
+
+	// Default user key of namespace read probe record
+	DefaultProbeKey = "healthcheck"
+
+	// Default namespace read probe timeout
+	DefaultProbeTimeout = time.Second
+)
+
+// Configures namespace read probe of readiness handlers.
+type Options struct {
+	// Namespace read probe is run in. Empty disables the probe.
+	// Ignored by registry handlers, which probe namespace of each registered connection.
+	Namespace string
+
+	// Set of probe record
+	Set string
+
+	// User key of probe record, [DefaultProbeKey] if nil. Missing record counts as successful probe.
+	Key interface{}
+
+	// Probe timeout, [DefaultProbeTimeout] if zero
+	Timeout time.Duration
+}
+
+// Health-check report.
+type Report struct {
+	Status  string         `json:"status"`
+	Clients []ClientStatus `json:"clients"`
+}
+
+// Health of a single client.
+type ClientStatus struct {
+	Name       string       `json:"name"`
+	Connected  bool         `json:"connected"`
+	Nodes      int          `json:"nodes"`
+	NodeStatus []NodeStatus `json:"node_status"`
+	Probe      *ProbeStatus `json:"probe,omitempty"`
+	Error      string       `json:"error,omitempty"`
+
+	// Circuit breaker state, empty if no breaker was added for the client
+	Breaker aerofactory.BreakerState `json:"breaker,omitempty"`
+}
+
+// Health of a single cluster node.
+type NodeStatus struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Active  bool   `json:"active"`
+}
+
+// Outcome of namespace read probe.
+type ProbeStatus struct {
+	Namespace string `json:"namespace"`
+	Set       string `json:"set,omitempty"`
+	OK        bool   `json:"ok"`
+	Latency   string `json:"latency"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Client to report under name, or error it could not be obtained with.
+// Readiness probe is run in namespace, unless it is empty.
+type namedClient struct {
+	name      string
+	namespace string
+	client    *aerospike.Client
+	err       error
+}
+
+// Serves health-check reports as JSON.
+// Readiness handlers respond with 503, unless every client is connected to at least one node, passes namespace probe
+// & its circuit breaker, if added, is not open. Liveness handlers always respond with 200, so cluster outages do not restart the process.
+type Handler struct {
+	readiness bool
+	options   Options
+	clients   func() []namedClient
+	breakers  map[string]*aerofactory.CircuitBreaker
+}
+
+// Returns liveness handler of client. Connection state is reported, but never fails the response.
+func Liveness(client *aerospike.Client) *Handler {
+	return &Handler{clients: singleClient(client, "")}
+}
+
+// Returns readiness handler of client.
+func Readiness(client *aerospike.Client, options Options) *Handler {
+	return &Handler{readiness: true, options: options, clients: singleClient(client, options.Namespace)}
+}
+
+// Returns liveness handler of every connection registered in [aerospikeurl.Registry].
+// Clients are obtained with [aerospikeurl.Registry.BuiltClient], so checks never connect
+// & connections not built yet are reported as not connected.
+func RegistryLiveness(registry *aerospikeurl.Registry) *Handler {
+	return &Handler{clients: registryClients(registry)}
+}
+
+// Returns readiness handler of every connection registered in [aerospikeurl.Registry].
+// Clients are obtained with [aerospikeurl.Registry.BuiltClient], so checks never connect
+// & connections not built yet fail readiness. Probe is run in namespace of each connection.
+func RegistryReadiness(registry *aerospikeurl.Registry, options Options) *Handler {
+	return &Handler{readiness: true, options: options, clients: registryClients(registry)}
+}
+
+// Adds circuit breaker state (See: [aerofactory.BreakerClient.Breaker]) to report of client named name:
+// [DefaultName] for handlers of a single client, registered name for registry handlers.
+// Open breaker fails readiness, half-open one does not, so probe calls can reach the cluster.
+// Should be called before handler serves requests. Returns handler.
+func (handler *Handler) WithBreaker(name string, breaker *aerofactory.CircuitBreaker) *Handler {
+	if handler.breakers == nil {
+		handler.breakers = map[string]*aerofactory.CircuitBreaker{}
+	}
+
+	handler.breakers[name] = breaker
+	return handler
+}
+
+// Writes JSON report (See: [Handler.Report]).
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := handler.Report()
+
+	code := http.StatusOK
+	if handler.readiness && report.Status != StatusUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	json.NewEncoder(w).Encode(report)
+}
+
+// Checks every client & returns health-check report.
+func (handler *Handler) Report() *Report {
+	report := &Report{Status: StatusUp, Clients: []ClientStatus{}}
+
+	for _, named := range handler.clients() {
+		clientStatus := handler.check(named)
+		if !healthy(clientStatus) {
+			report.Status = StatusDown
+		}
+
+		report.Clients = append(report.Clients, clientStatus)
+	}
+
+	return report
+}
+
+// Checks connection state, nodes, circuit breaker & (for readiness handlers) namespace probe of client.
+func (handler *Handler) check(named namedClient) ClientStatus {
+	clientStatus := ClientStatus{Name: named.name, NodeStatus: []NodeStatus{}}
+
+	if breaker := handler.breakers[named.name]; breaker != nil {
+		clientStatus.Breaker = breaker.State()
+	}
+
+	if named.err != nil {
+		clientStatus.Error = named.err.Error()
+		return clientStatus
+	}
+
+	if named.client == nil {
+		clientStatus.Error = ErrNilClient.Error()
+		return clientStatus
+	}
+
+	clientStatus.Connected, clientStatus.NodeStatus = connectionStatus(named.client)
+	clientStatus.Nodes = len(clientStatus.NodeStatus)
+
+	if handler.readiness && named.namespace != "" && clientStatus.Connected {
+		clientStatus.Probe = probe(named.client, named.namespace, handler.options)
+	}
+
+	return clientStatus
+}
+
+// Reports whether client is connected to at least one node, passed namespace probe, if it was run,
+// & its circuit breaker is not open.
+func healthy(clientStatus ClientStatus) bool {
+	if !clientStatus.Connected || clientStatus.Nodes == 0 || clientStatus.Breaker == aerofactory.BreakerOpen {
+		return false
+	}
+
+	return clientStatus.Probe == nil || clientStatus.Probe.OK
+}
+
+// Returns connection state & status of every node of client. Overridden in tests.
+var connectionStatus = func(client *aerospike.Client) (bool, []NodeStatus) {
+	nodeStatuses := []NodeStatus{}
+
+	for _, node := range client.GetNodes() {
+		address := ""
+		if host := node.GetHost(); host != nil {
+			address = host.String()
+		}
+
+		nodeStatuses = append(nodeStatuses, NodeStatus{node.GetName(), address, node.IsActive()})
+	}
+
+	return client.IsConnected(), nodeStatuses
+}
+
+// Reads probe record. Overridden in tests.
+var getRecord = func(client *aerospike.Client, policy *aerospike.BasePolicy, key *aerospike.Key) aerospike.Error {
+	_, err := client.GetHeader(policy, key)
+	return err
+}
+
+// Reads probe record from namespace. Missing record counts as success, as the server answered for the namespace.
+func probe(client *aerospike.Client, namespace string, options Options) *ProbeStatus {
+	probeStatus := &ProbeStatus{Namespace: namespace, Set: options.Set}
+
+	userKey := options.Key
+	if userKey == nil {
+		userKey = DefaultProbeKey
+	}
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+
+	key, err := aerospike.NewKey(namespace, options.Set, userKey)
+	if err != nil {
+		probeStatus.Error = err.Error()
+		return probeStatus
+	}
+
+	policy := aerospike.NewPolicy()
+	policy.TotalTimeout = timeout
+	policy.MaxRetries = 0
+
+	started := time.Now()
+	err = getRecord(client, policy, key)
+	probeStatus.Latency = time.Since(started).String()
+
+	if err != nil && !err.Matches(types.KEY_NOT_FOUND_ERROR) {
+		probeStatus.Error = err.Error()
+		return probeStatus
+	}
+
+	probeStatus.OK = true
+	return probeStatus
+}
+
+// Returns client list of a single client reported under [DefaultName] & probed in namespace.
+func singleClient(client *aerospike.Client, namespace string) func() []namedClient {
+	return func() []namedClient {
+		return []namedClient{{name: DefaultName, namespace: namespace, client: client}}
+	}
+}
+
+// Returns client list of every connection registered in registry.
+func registryClients(registry *aerospikeurl.Registry) func() []namedClient {
+	return func() []namedClient {
+		clients := []namedClient{}
+
+		for _, name := range registry.Names() {
+			named := namedClient{name: name}
+
+			if clientFactory, err := registry.Factory(name); err == nil {
+				named.namespace = clientFactory.GetNamespace()
+			}
+
+			named.client, named.err = registry.BuiltClient(name)
+			if named.client == nil && named.err == nil {
+				named.err = ErrNotBuilt
+			}
+
+			clients = append(clients, named)
+		}
+
+		return clients
+	}
+}
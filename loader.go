@@ -0,0 +1,342 @@
+// Root package
+package aerospikeurl
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+	"github.com/tiptophelmet/aerospike-url/clientpolicy"
+)
+
+// Configuration layer a resolved value came from.
+type Source string
+
+const (
+	// Value was not set by any layer, [aerospike.NewClientPolicy] default is used.
+	//
+	// [aerospike.NewClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#NewClientPolicy
+	SourceDefault Source = "default"
+
+	// Value was taken from [Loader.ConnStr].
+	SourceURL Source = "url"
+
+	// Value was taken from [Loader.ConfigFile].
+	SourceFile Source = "file"
+
+	// Value was taken from `AEROSPIKE_*` environment variable.
+	SourceEnv Source = "env"
+
+	// Value was taken from flag registered by [Loader.BindFlags].
+	SourceFlag Source = "flag"
+)
+
+const (
+	envPrefix  = "AEROSPIKE_"
+	flagPrefix = "aerospike-"
+)
+
+// Describes a single loader key: its layered name & the field it is reported under.
+type loaderKey struct {
+	name  string
+	field string
+}
+
+// Returns every key supported by [Loader] in resolution order.
+// Address keys are followed by [clientpolicy.Params].
+func loaderKeys() []loaderKey {
+	keys := []loaderKey{
+		{"hosts", "Hosts"},
+		{"namespace", "Namespace"},
+		{"set", "Set"},
+		{"user", "User"},
+		{"password", "Password"},
+	}
+
+	for _, param := range clientpolicy.Params() {
+		keys = append(keys, loaderKey{param.Name, param.Field})
+	}
+
+	return keys
+}
+
+// Builds [aerofactory.AerospikeClientFactory] from a precedence stack of configuration layers.
+// Every next layer overrides values of the previous one:
+//
+//  1. connection string [Loader.ConnStr]
+//  2. JSON configuration file [Loader.ConfigFile]
+//  3. `AEROSPIKE_*` environment variables, e.g. `AEROSPIKE_HOSTS`, `AEROSPIKE_TIMEOUT`
+//  4. flags registered with [Loader.BindFlags], e.g. `-aerospike-hosts`, `-aerospike-timeout`
+//
+// Keys of JSON file, environment variables & flags are named after URL query parameters (See: [clientpolicy.Params]),
+// extended with `hosts` (comma-separated `hostname:port` list), `namespace`, `set`, `user` & `password`.
+type Loader struct {
+	// Aerospike connection string, e.g. `aerospike://127.0.0.1:3000/aero-namespace-001?timeout=10s`
+	ConnStr string
+
+	// Path to JSON configuration file, e.g. `{"hosts": ["127.0.0.1:3000"], "timeout": "10s"}`
+	ConfigFile string
+
+	flagSet *flag.FlagSet
+	sources map[string]Source
+}
+
+// Registers `-aerospike-*` flags on [flag.FlagSet].
+// Only flags explicitly set on command line override other layers.
+func (loader *Loader) BindFlags(flagSet *flag.FlagSet) {
+	for _, key := range loaderKeys() {
+		name := flagPrefix + strings.ReplaceAll(key.name, "_", "-")
+		flagSet.String(name, "", fmt.Sprintf("Aerospike %s (overrides %s%s)", key.name, envPrefix, strings.ToUpper(key.name)))
+	}
+
+	loader.flagSet = flagSet
+}
+
+// Resolves all layers & builds [aerofactory.AerospikeClientFactory].
+// Returns error, if any layer is invalid or resolved configuration is not a valid Aerospike URL.
+func (loader *Loader) Load() (*aerofactory.AerospikeClientFactory, error) {
+	values := map[string]string{}
+	loader.sources = map[string]Source{}
+
+	query, err := loader.loadConnStr(values)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loader.loadConfigFile(values); err != nil {
+		return nil, err
+	}
+
+	loader.loadEnv(values)
+	loader.loadFlags(values)
+
+	hosts, err := parseHosts(values["hosts"])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, param := range clientpolicy.Params() {
+		if value, ok := values[param.Name]; ok {
+			query.Set(param.Name, value)
+		}
+	}
+
+	connURL := &url.URL{
+		Scheme:   "aerospike",
+		Host:     net.JoinHostPort(hosts[0].Name, strconv.Itoa(hosts[0].Port)),
+		Path:     strings.TrimSuffix("/"+values["namespace"]+"/"+values["set"], "/"),
+		RawQuery: query.Encode(),
+	}
+
+	if password, ok := values["password"]; ok {
+		connURL.User = url.UserPassword(values["user"], password)
+	} else if user, ok := values["user"]; ok {
+		connURL.User = url.User(user)
+	}
+
+	aeroURL, err := aerourl.Init(connURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	clientFactory, err := generateClientFactory(aeroURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hosts) > 1 {
+		clientFactory.SetHosts(hosts...)
+	}
+
+	return clientFactory, nil
+}
+
+// Returns configuration layer every resolved field came from, keyed by [aerospike.ClientPolicy] field name
+// (plus `Hosts`, `Namespace` & `Set`). Fields not set by any layer are reported as [SourceDefault].
+// Returns nil, if [Loader.Load] was not called.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+func (loader *Loader) Sources() map[string]Source {
+	if loader.sources == nil {
+		return nil
+	}
+
+	sources := map[string]Source{}
+	for _, key := range loaderKeys() {
+		source, ok := loader.sources[key.name]
+		if !ok {
+			source = SourceDefault
+		}
+
+		sources[key.field] = source
+	}
+
+	return sources
+}
+
+// Loads connection string layer into values.
+// Returns URL query, so parameters unknown to loader are kept as is.
+func (loader *Loader) loadConnStr(values map[string]string) (url.Values, error) {
+	if strings.TrimSpace(loader.ConnStr) == "" {
+		return url.Values{}, nil
+	}
+
+	connURL, err := url.Parse(loader.ConnStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if connURL.Scheme != "aerospike" {
+		return nil, aerourl.ErrInvalidScheme
+	}
+
+	if connURL.Host != "" {
+		loader.set(values, "hosts", connURL.Host, SourceURL)
+	}
+
+	pathParts := strings.Split(strings.TrimPrefix(connURL.Path, "/"), "/")
+	if namespace := pathParts[0]; namespace != "" {
+		loader.set(values, "namespace", namespace, SourceURL)
+	}
+
+	if len(pathParts) > 1 && pathParts[1] != "" {
+		loader.set(values, "set", pathParts[1], SourceURL)
+	}
+
+	if connURL.User != nil {
+		loader.set(values, "user", connURL.User.Username(), SourceURL)
+
+		if password, ok := connURL.User.Password(); ok {
+			loader.set(values, "password", password, SourceURL)
+		}
+	}
+
+	query := connURL.Query()
+	for _, param := range clientpolicy.Params() {
+		if query.Has(param.Name) {
+			loader.set(values, param.Name, query.Get(param.Name), SourceURL)
+		}
+	}
+
+	return query, nil
+}
+
+// Loads JSON configuration file layer into values.
+// Scalar values are used as is, `hosts` can also be a list of `hostname:port` strings.
+func (loader *Loader) loadConfigFile(values map[string]string) error {
+	if loader.ConfigFile == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(loader.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	config := map[string]interface{}{}
+	if err := decoder.Decode(&config); err != nil {
+		return fmt.Errorf("%s: %w", loader.ConfigFile, err)
+	}
+
+	known := map[string]bool{}
+	for _, key := range loaderKeys() {
+		known[key.name] = true
+	}
+
+	for name, value := range config {
+		if !known[name] {
+			return fmt.Errorf("%w: %s", ErrUnknownConfigKey, name)
+		}
+
+		if list, ok := value.([]interface{}); ok {
+			items := make([]string, 0, len(list))
+			for _, item := range list {
+				items = append(items, fmt.Sprint(item))
+			}
+
+			loader.set(values, name, strings.Join(items, ","), SourceFile)
+			continue
+		}
+
+		loader.set(values, name, fmt.Sprint(value), SourceFile)
+	}
+
+	return nil
+}
+
+// Loads `AEROSPIKE_*` environment variables layer into values.
+func (loader *Loader) loadEnv(values map[string]string) {
+	for _, key := range loaderKeys() {
+		if value, ok := os.LookupEnv(envPrefix + strings.ToUpper(key.name)); ok {
+			loader.set(values, key.name, value, SourceEnv)
+		}
+	}
+}
+
+// Loads explicitly set flags layer into values.
+func (loader *Loader) loadFlags(values map[string]string) {
+	if loader.flagSet == nil {
+		return
+	}
+
+	setFlags := map[string]*flag.Flag{}
+	loader.flagSet.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = f
+	})
+
+	for _, key := range loaderKeys() {
+		if f, ok := setFlags[flagPrefix+strings.ReplaceAll(key.name, "_", "-")]; ok {
+			loader.set(values, key.name, f.Value.String(), SourceFlag)
+		}
+	}
+}
+
+// Sets value & records its source.
+func (loader *Loader) set(values map[string]string, name string, value string, source Source) {
+	values[name] = value
+	loader.sources[name] = source
+}
+
+// Parses comma-separated `hostname:port` list into [aerospike.Host] list.
+//
+// [aerospike.Host]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Host
+func parseHosts(hostsStr string) ([]*aerospike.Host, error) {
+	hosts := []*aerospike.Host{}
+
+	for _, hostStr := range strings.Split(hostsStr, ",") {
+		hostStr = strings.TrimSpace(hostStr)
+		if hostStr == "" {
+			continue
+		}
+
+		hostname, portStr, err := net.SplitHostPort(hostStr)
+		if err != nil || hostname == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHost, hostStr)
+		}
+
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidHost, hostStr)
+		}
+
+		hosts = append(hosts, aerospike.NewHost(hostname, port))
+	}
+
+	if len(hosts) == 0 {
+		return nil, ErrNoHosts
+	}
+
+	return hosts, nil
+}
@@ -0,0 +1,100 @@
+// Root package
+package aerospikeurl
+
+import (
+	"log/slog"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+	"github.com/tiptophelmet/aerospike-url/clientoptions"
+	"github.com/tiptophelmet/aerospike-url/clientpolicy"
+)
+
+// Valid `auth_mode` URL query parameter values.
+var authModes = map[string]bool{"auth_mode_internal": true, "auth_mode_external": true, "auth_mode_pki": true}
+
+// Logs how Aerospike URL was resolved into factory:
+// resolved address at info level, every parameter at debug level,
+// unknown & invalid parameters at warn level, as they are ignored or replaced by zero values.
+func logResolution(logger *slog.Logger, aeroURL *aerourl.AerospikeURL, clientFactory *aerofactory.AerospikeClientFactory) {
+	if logger == nil {
+		return
+	}
+
+	logger.Info("aerospike url resolved",
+		"url", aeroURL.GetNetURL().Redacted(),
+		"hostname", clientFactory.GetHostname(), "port", clientFactory.GetPort(),
+		"namespace", clientFactory.GetNamespace(), "set", clientFactory.GetSet(),
+		"mode", clientFactory.GetMode())
+
+	query := aeroURL.GetNetURL().Query()
+	known := map[string]bool{}
+
+	for _, param := range clientpolicy.Params() {
+		known[param.Name] = true
+
+		if !query.Has(param.Name) {
+			logger.Debug("aerospike url parameter not set, default used", "param", param.Name)
+			continue
+		}
+
+		value := query.Get(param.Name)
+
+		switch {
+		case param.Name == "auth_mode" && !authModes[value]:
+			logger.Warn("aerospike url parameter is invalid, ignored", "param", param.Name, "value", value)
+		case !validPolicyValue(param.Field, value):
+			logger.Warn("aerospike url parameter is invalid, zero value used", "param", param.Name, "value", value)
+		default:
+			logger.Debug("aerospike url parameter resolved", "param", param.Name, "value", value)
+		}
+	}
+
+	for _, name := range clientoptions.Params() {
+		known[name] = true
+
+		if query.Has(name) {
+			logger.Debug("aerospike url parameter resolved", "param", name, "value", query.Get(name))
+		}
+	}
+
+	unknown := []string{}
+	for name := range query {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		logger.Warn("aerospike url parameter is unknown, ignored", "param", name)
+	}
+}
+
+// Reports whether value can be parsed into [aerospike.ClientPolicy] field.
+//
+// [aerospike.ClientPolicy]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#ClientPolicy
+func validPolicyValue(fieldName string, value string) bool {
+	field, ok := reflect.TypeOf(aerospike.ClientPolicy{}).FieldByName(fieldName)
+	if !ok {
+		return true
+	}
+
+	var err error
+
+	switch {
+	case field.Type == reflect.TypeOf(time.Duration(0)):
+		_, err = time.ParseDuration(value)
+	case field.Type.Kind() == reflect.Int:
+		_, err = strconv.Atoi(value)
+	case field.Type.Kind() == reflect.Bool:
+		_, err = strconv.ParseBool(value)
+	}
+
+	return err == nil
+}
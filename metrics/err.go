@@ -0,0 +1,13 @@
+// Metrics package.
+// Statistics of clients built by [aerofactory.AerospikeClientFactory] are exported in Prometheus text format & via [expvar] here.
+package metrics
+
+import "errors"
+
+var (
+	// Client is already added to [Exporter] under the same name
+	ErrClientExists = errors.New("aerospike client is already exported under this name")
+
+	// Circuit breaker is already added to [Exporter] under the same name
+	ErrBreakerExists = errors.New("aerospike circuit breaker is already exported under this name")
+)
@@ -0,0 +1,469 @@
+// Metrics package.
+// Statistics of clients built by [aerofactory.AerospikeClientFactory] are exported in Prometheus text format & via [expvar] here.
+//
+// [Exporter] periodically reads [aerospike.Client.Stats] & cluster node info of every added client
+// & serves the latest snapshot, so scrapes never block on the cluster:
+//
+//	aerospike_client_connected{client="orders"} 1
+//	aerospike_client_nodes{client="orders"} 2
+//	aerospike_client_node_active{client="orders",node="BB9020011AC4202",address="10.0.0.1:3000"} 1
+//	aerospike_client_open_connections{client="orders",node="BB9020011AC4202"} 12
+//	aerospike_client_connections_attempts_total{client="orders",node="BB9020011AC4202"} 15
+//
+// State of circuit breakers added with [Exporter.AddBreaker] is exported as one gauge per state:
+//
+//	aerospike_client_breaker_state{client="orders",state="open"} 1
+//
+// [aerospike.Client.Stats]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Stats
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	aerospikeurl "github.com/tiptophelmet/aerospike-url"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Default interval [Exporter] reads client statistics at.
+const DefaultInterval = 15 * time.Second
+
+// Prefix of every exported metric name.
+const namePrefix = "aerospike_client_"
+
+// Aggregated statistics key of [aerospike.Client.Stats], skipped as Prometheus aggregates per-node series itself.
+//
+// [aerospike.Client.Stats]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Stats
+const aggregatedStatsKey = "cluster-aggregated-stats"
+
+// Per-node statistics exported as counters with `_total` suffix, the rest are exported as gauges.
+var counterStats = map[string]bool{
+	"connections-attempts":   true,
+	"connections-successful": true,
+	"connections-failed":     true,
+	"connections-pool-empty": true,
+	"closed-connections":     true,
+	"tends-total":            true,
+	"tends-successful":       true,
+	"tends-failed":           true,
+	"partition-map-updates":  true,
+	"node-added-count":       true,
+	"node-removed-count":     true,
+}
+
+// Help texts of metrics not derived from [aerospike.Client.Stats].
+//
+// [aerospike.Client.Stats]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Stats
+var helpTexts = map[string]string{
+	"connected":     "Whether client is connected to the cluster.",
+	"nodes":         "Number of cluster nodes known to client.",
+	"node_active":   "Whether cluster node is active.",
+	"breaker_state": "Whether client circuit breaker is in state.",
+}
+
+// Node info read from client.
+type nodeInfo struct {
+	name    string
+	address string
+	active  bool
+}
+
+// Statistics of a single client at the time of collection.
+type clientSnapshot struct {
+	Connected bool                          `json:"connected"`
+	Nodes     int                           `json:"nodes"`
+	NodeStats map[string]map[string]float64 `json:"node_stats"`
+	Error     string                        `json:"error,omitempty"`
+
+	nodeInfos []nodeInfo
+}
+
+// Periodically reads statistics of added clients & exposes them in Prometheus text format
+// ([Exporter.ServeHTTP]) & via [expvar] ([Exporter.Publish]).
+type Exporter struct {
+	interval time.Duration
+
+	mu        sync.RWMutex
+	clients   map[string]func() (*aerospike.Client, error)
+	snapshots map[string]*clientSnapshot
+
+	breakers      map[string]*aerofactory.CircuitBreaker
+	breakerStates map[string]aerofactory.BreakerState
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	running  sync.WaitGroup
+}
+
+// Creates [Exporter] reading statistics at interval, [DefaultInterval] if interval is not positive.
+// Statistics are not read until [Exporter.Start] or [Exporter.Collect] is called.
+func NewExporter(interval time.Duration) *Exporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Exporter{
+		interval:      interval,
+		clients:       map[string]func() (*aerospike.Client, error){},
+		snapshots:     map[string]*clientSnapshot{},
+		breakers:      map[string]*aerofactory.CircuitBreaker{},
+		breakerStates: map[string]aerofactory.BreakerState{},
+		stop:          make(chan struct{}),
+	}
+}
+
+// Returns client label of [aerofactory.AerospikeClientFactory]: its sorted seed hosts, namespace & set,
+// e.g. `10.0.0.1:3000,10.0.0.2:3000/aero-namespace-001`. Credentials & query parameters are never included.
+func Label(clientFactory *aerofactory.AerospikeClientFactory) string {
+	hosts := []string{}
+	for _, host := range clientFactory.GetHosts() {
+		hosts = append(hosts, net.JoinHostPort(host.Name, strconv.Itoa(host.Port)))
+	}
+
+	sort.Strings(hosts)
+
+	label := strings.Join(hosts, ",") + "/" + clientFactory.GetNamespace()
+	if set := clientFactory.GetSet(); set != "" {
+		label += "/" + set
+	}
+
+	return label
+}
+
+// Adds client exported under `client` label name.
+// Returns error, if name is already taken.
+func (exporter *Exporter) Add(name string, client *aerospike.Client) error {
+	return exporter.add(name, func() (*aerospike.Client, error) { return client, nil })
+}
+
+// Adds client built by factory, exported under its hosts & namespace (See: [Label]).
+// Returns error, if a client of the same hosts & namespace is already exported.
+func (exporter *Exporter) AddFactory(clientFactory *aerofactory.AerospikeClientFactory, client *aerospike.Client) error {
+	return exporter.Add(Label(clientFactory), client)
+}
+
+// Adds every connection registered in [aerospikeurl.Registry], exported under registered names.
+// Clients are obtained with [aerospikeurl.Registry.BuiltClient] at every collection, so collection never connects
+// & connections not built yet are exported as disconnected. Returns error, if any name is already taken.
+func (exporter *Exporter) AddRegistry(registry *aerospikeurl.Registry) error {
+	for _, name := range registry.Names() {
+		name := name
+
+		err := exporter.add(name, func() (*aerospike.Client, error) { return registry.BuiltClient(name) })
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Adds circuit breaker (See: [aerofactory.BreakerClient.Breaker]) exported under `client` label name,
+// usually the name its client is exported under. Its state is read at every collection.
+// Returns error, if a breaker is already exported under name.
+func (exporter *Exporter) AddBreaker(name string, breaker *aerofactory.CircuitBreaker) error {
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+
+	if _, ok := exporter.breakers[name]; ok {
+		return fmt.Errorf("%w: %s", ErrBreakerExists, name)
+	}
+
+	exporter.breakers[name] = breaker
+	return nil
+}
+
+// Removes client & circuit breaker exported under name together with their latest statistics.
+func (exporter *Exporter) Remove(name string) {
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+
+	delete(exporter.clients, name)
+	delete(exporter.snapshots, name)
+	delete(exporter.breakers, name)
+	delete(exporter.breakerStates, name)
+}
+
+// Reads statistics in background every interval, starting immediately.
+// Stopped with [Exporter.Stop].
+func (exporter *Exporter) Start() {
+	exporter.running.Add(1)
+
+	go func() {
+		defer exporter.running.Done()
+
+		ticker := time.NewTicker(exporter.interval)
+		defer ticker.Stop()
+
+		for {
+			exporter.Collect()
+
+			select {
+			case <-exporter.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stops background reading started with [Exporter.Start] & waits for collection in progress.
+func (exporter *Exporter) Stop() {
+	exporter.stopOnce.Do(func() { close(exporter.stop) })
+	exporter.running.Wait()
+}
+
+// Reads statistics of every added client & state of every added circuit breaker now.
+func (exporter *Exporter) Collect() {
+	exporter.mu.RLock()
+	clients := make(map[string]func() (*aerospike.Client, error), len(exporter.clients))
+	for name, getClient := range exporter.clients {
+		clients[name] = getClient
+	}
+
+	breakerStates := make(map[string]aerofactory.BreakerState, len(exporter.breakers))
+	for name, breaker := range exporter.breakers {
+		breakerStates[name] = breaker.State()
+	}
+	exporter.mu.RUnlock()
+
+	snapshots := make(map[string]*clientSnapshot, len(clients))
+	for name, getClient := range clients {
+		snapshots[name] = collect(getClient)
+	}
+
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+
+	for name, snapshot := range snapshots {
+		// Skip clients removed during collection
+		if _, ok := exporter.clients[name]; ok {
+			exporter.snapshots[name] = snapshot
+		}
+	}
+
+	for name, state := range breakerStates {
+		if _, ok := exporter.breakers[name]; ok {
+			exporter.breakerStates[name] = state
+		}
+	}
+}
+
+// Writes the latest statistics in Prometheus text exposition format.
+func (exporter *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(exporter.Text()))
+}
+
+// Returns the latest statistics in Prometheus text exposition format.
+func (exporter *Exporter) Text() string {
+	exporter.mu.RLock()
+	defer exporter.mu.RUnlock()
+
+	families, counters, statHelpTexts := map[string][]string{}, map[string]bool{}, map[string]string{}
+	addSample := func(metric string, labels [][2]string, value float64) {
+		families[metric] = append(families[metric], namePrefix+metric+formatLabels(labels)+" "+strconv.FormatFloat(value, 'g', -1, 64))
+	}
+
+	for name, snapshot := range exporter.snapshots {
+		clientLabel := [2]string{"client", name}
+
+		addSample("connected", [][2]string{clientLabel}, boolValue(snapshot.Connected))
+		addSample("nodes", [][2]string{clientLabel}, float64(snapshot.Nodes))
+
+		for _, node := range snapshot.nodeInfos {
+			addSample("node_active", [][2]string{clientLabel, {"node", node.name}, {"address", node.address}}, boolValue(node.active))
+		}
+
+		for node, stats := range snapshot.NodeStats {
+			for stat, value := range stats {
+				metric := metricName(stat)
+				if counterStats[stat] {
+					metric = counterName(metric)
+					counters[metric] = true
+				}
+
+				statHelpTexts[metric] = fmt.Sprintf("Aerospike client statistic %s.", stat)
+				addSample(metric, [][2]string{clientLabel, {"node", node}}, value)
+			}
+		}
+	}
+
+	for name, breakerState := range exporter.breakerStates {
+		for _, state := range []aerofactory.BreakerState{aerofactory.BreakerClosed, aerofactory.BreakerOpen, aerofactory.BreakerHalfOpen} {
+			addSample("breaker_state", [][2]string{{"client", name}, {"state", string(state)}}, boolValue(breakerState == state))
+		}
+	}
+
+	metrics := make([]string, 0, len(families))
+	for metric := range families {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	var builder strings.Builder
+	for _, metric := range metrics {
+		metricType, help := "gauge", helpTexts[metric]
+		if counters[metric] {
+			metricType = "counter"
+		}
+
+		if help == "" {
+			help = statHelpTexts[metric]
+		}
+
+		samples := families[metric]
+		sort.Strings(samples)
+
+		builder.WriteString(fmt.Sprintf("# HELP %s%s %s\n", namePrefix, metric, help))
+		builder.WriteString(fmt.Sprintf("# TYPE %s%s %s\n", namePrefix, metric, metricType))
+		builder.WriteString(strings.Join(samples, "\n") + "\n")
+	}
+
+	return builder.String()
+}
+
+// Publishes the latest statistics as [expvar] variable (See: [Exporter.Var]).
+// Panics, if variable name is already published (See: [expvar.Publish]).
+func (exporter *Exporter) Publish(name string) {
+	expvar.Publish(name, exporter.Var())
+}
+
+// Returns the latest statistics as [expvar.Var], keyed by client name.
+func (exporter *Exporter) Var() expvar.Var {
+	return expvar.Func(func() interface{} {
+		exporter.mu.RLock()
+		defer exporter.mu.RUnlock()
+
+		snapshots := make(map[string]clientSnapshot, len(exporter.snapshots))
+		for name, snapshot := range exporter.snapshots {
+			snapshots[name] = *snapshot
+		}
+
+		return snapshots
+	})
+}
+
+// Adds client getter under name.
+func (exporter *Exporter) add(name string, getClient func() (*aerospike.Client, error)) error {
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+
+	if _, ok := exporter.clients[name]; ok {
+		return fmt.Errorf("%w: %s", ErrClientExists, name)
+	}
+
+	exporter.clients[name] = getClient
+	return nil
+}
+
+// Reads statistics & node info of client.
+func collect(getClient func() (*aerospike.Client, error)) *clientSnapshot {
+	snapshot := &clientSnapshot{NodeStats: map[string]map[string]float64{}, nodeInfos: []nodeInfo{}}
+
+	client, err := getClient()
+	if err != nil {
+		snapshot.Error = err.Error()
+		return snapshot
+	}
+
+	connected, nodeInfos, stats, err := readClient(client)
+	if err != nil {
+		snapshot.Error = err.Error()
+	}
+
+	snapshot.Connected = connected
+	snapshot.Nodes = len(nodeInfos)
+	snapshot.nodeInfos = nodeInfos
+
+	for node, nodeStats := range stats {
+		nodeStatsMap, ok := nodeStats.(map[string]interface{})
+		if !ok || node == aggregatedStatsKey {
+			continue
+		}
+
+		snapshot.NodeStats[node] = map[string]float64{}
+		for stat, value := range nodeStatsMap {
+			if number, ok := value.(float64); ok {
+				snapshot.NodeStats[node][stat] = number
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// Reads connection state, node info & [aerospike.Client.Stats] of client. Overridden in tests.
+//
+// [aerospike.Client.Stats]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client.Stats
+var readClient = func(client *aerospike.Client) (bool, []nodeInfo, map[string]interface{}, error) {
+	if client == nil {
+		return false, []nodeInfo{}, nil, nil
+	}
+
+	nodeInfos := []nodeInfo{}
+	for _, node := range client.GetNodes() {
+		address := ""
+		if host := node.GetHost(); host != nil {
+			address = host.String()
+		}
+
+		nodeInfos = append(nodeInfos, nodeInfo{node.GetName(), address, node.IsActive()})
+	}
+
+	stats, err := client.Stats()
+	if err != nil {
+		return client.IsConnected(), nodeInfos, nil, err
+	}
+
+	return client.IsConnected(), nodeInfos, stats, nil
+}
+
+// Converts statistic key (e.g. `open-connections`) into metric name (e.g. `open_connections`).
+func metricName(stat string) string {
+	return strings.Map(func(char rune) rune {
+		if (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') {
+			return char
+		}
+
+		return '_'
+	}, stat)
+}
+
+// Adds `_total` suffix required for counters by Prometheus naming conventions, unless metric name has it already.
+func counterName(metric string) string {
+	if strings.HasSuffix(metric, "_total") {
+		return metric
+	}
+
+	return metric + "_total"
+}
+
+// Formats labels as `{name="value",...}`, escaping values per Prometheus text format.
+func formatLabels(labels [][2]string) string {
+	pairs := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(label[1])
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label[0], value))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Converts bool into 1 or 0.
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
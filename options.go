@@ -0,0 +1,41 @@
+// Root package
+package aerospikeurl
+
+import "log/slog"
+
+// Configures [Parse].
+type Option func(*parseOptions)
+
+// Options collected from [Option] list.
+type parseOptions struct {
+	logger       *slog.Logger
+	chaosAllowed bool
+}
+
+// Logs URL parameter resolution with logger & sets it on the resulting factory
+// (See: [aerofactory.AerospikeClientFactory.SetLogger]), so connection attempts & failures are logged too.
+// Password is never logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(options *parseOptions) {
+		options.logger = logger
+	}
+}
+
+// Allows fault injection declared with `chaos_*` URL query parameters
+// (See: [aerofactory.AerospikeClientFactory.AllowChaos]). Without this option, chaos parameters are ignored,
+// so fault injection can never be enabled in production from connection string alone.
+func WithChaosAllowed() Option {
+	return func(options *parseOptions) {
+		options.chaosAllowed = true
+	}
+}
+
+// Applies options.
+func newParseOptions(opts []Option) *parseOptions {
+	options := &parseOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
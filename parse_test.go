@@ -1,9 +1,12 @@
 package aerospikeurl
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
 	"github.com/tiptophelmet/aerospike-url/aerourl"
+	"github.com/tiptophelmet/aerospike-url/clientoptions"
 )
 
 func TestGenerateClientFactoryWithInvalidAeroURL(t *testing.T) {
@@ -57,3 +60,69 @@ func TestParse(t *testing.T) {
 		t.Errorf("got: %v, want: *factory.ClientFactory != nil", clientFactory)
 	}
 }
+
+func TestParseWithSet(t *testing.T) {
+	clientFactory, err := Parse("aerospike://127.0.0.1:3000/aero-namespace-001/users")
+
+	if err != nil {
+		t.Fatalf("got: %v, want error = nil", err)
+	}
+
+	if clientFactory.GetNamespace() != "aero-namespace-001" || clientFactory.GetSet() != "users" {
+		t.Errorf("got: %v/%v, want: aero-namespace-001/users", clientFactory.GetNamespace(), clientFactory.GetSet())
+	}
+
+	if Canonical(clientFactory) != "aerospike://127.0.0.1:3000/aero-namespace-001/users" {
+		t.Errorf("got: %v, want: set in canonical connection string", Canonical(clientFactory))
+	}
+}
+
+func TestParseWithMode(t *testing.T) {
+	clientFactory, err := Parse("aerospike://127.0.0.1:3000/aero-namespace-001?mode=readonly")
+
+	if err != nil {
+		t.Fatalf("got: %v, want error = nil", err)
+	}
+
+	if clientFactory.GetMode() != aerofactory.ModeReadOnly {
+		t.Errorf("got: %v, want: %v", clientFactory.GetMode(), aerofactory.ModeReadOnly)
+	}
+
+	if _, err := Parse("aerospike://127.0.0.1:3000/aero-namespace-001?mode=writeonly"); !errors.Is(err, clientoptions.ErrInvalidMode) {
+		t.Errorf("got: %v, want: error is clientoptions.ErrInvalidMode", err)
+	}
+}
+
+func TestParseInMemory(t *testing.T) {
+	clientFactory, err := Parse("mem://orders/aero-namespace-001")
+
+	if err != nil {
+		t.Fatalf("got: %v, want error = nil", err)
+	}
+
+	if !clientFactory.GetInMemory() || clientFactory.GetHostname() != "orders" {
+		t.Errorf("got: %v, %v, want: in-memory factory of orders", clientFactory.GetInMemory(), clientFactory.GetHostname())
+	}
+
+	if Canonical(clientFactory) != "aerospike+mem://orders:0/aero-namespace-001" {
+		t.Errorf("got: %v, want: in-memory scheme in canonical connection string", Canonical(clientFactory))
+	}
+}
+
+func TestParseWithChaosAllowed(t *testing.T) {
+	connStr := "aerospike://127.0.0.1:3000/aero-namespace-001?chaos_error_rate=0.01&chaos_seed=42"
+
+	clientFactory, err := Parse(connStr)
+	if err != nil {
+		t.Fatalf("got: %v, want error = nil", err)
+	}
+
+	if clientFactory.GetChaos() == nil || clientFactory.ChaosAllowed() {
+		t.Errorf("got: %v, %v, want: chaos parsed, but not allowed", clientFactory.GetChaos(), clientFactory.ChaosAllowed())
+	}
+
+	clientFactory, _ = Parse(connStr, WithChaosAllowed())
+	if !clientFactory.ChaosAllowed() {
+		t.Error("got: chaos not allowed, want: chaos allowed with WithChaosAllowed")
+	}
+}
@@ -0,0 +1,27 @@
+// Record URL package.
+// Record URLs (textual pointers to single Aerospike records) are parsed into & formatted from [aerospike.Key] here.
+//
+// [aerospike.Key]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Key
+package recordurl
+
+import "errors"
+
+var (
+	// Record URL path is not `/namespace/set/key` or `/namespace/set/digest/<hex>`
+	ErrInvalidPath = errors.New("invalid record url path, want: /namespace/set/key or /namespace/set/digest/<hex>")
+
+	// `key_type` URL query parameter is not one of `string`, `int` or `bytes`
+	ErrInvalidKeyType = errors.New("invalid key_type, want: string, int or bytes")
+
+	// User key cannot be parsed as `key_type`
+	ErrInvalidKey = errors.New("record key does not match key_type")
+
+	// Digest is not 20 hex-encoded bytes
+	ErrInvalidDigest = errors.New("invalid record digest, want: 40 hex characters")
+
+	// Nil key was passed to [Format]
+	ErrNilKey = errors.New("record key cannot be nil")
+
+	// User key value cannot be expressed in record URL
+	ErrUnsupportedKey = errors.New("record key type is not supported, want: string, integer or bytes")
+)
@@ -0,0 +1,184 @@
+// Record URL package.
+// Record URLs (textual pointers to single Aerospike records) are parsed into & formatted from [aerospike.Key] here:
+//
+//	aerospike://127.0.0.1:3000/aero-namespace-001/users/user-001
+//	aerospike://127.0.0.1:3000/aero-namespace-001/users/42?key_type=int
+//	aerospike://127.0.0.1:3000/aero-namespace-001/users/0a0b0c?key_type=bytes
+//	aerospike://127.0.0.1:3000/aero-namespace-001/users/digest/<40 hex characters>
+//	aerospike://127.0.0.1:3000/aero-namespace-001/users/?key_type=string (empty key)
+//
+// Namespace, set & key are path-escaped, so keys may contain `/`. Set may be empty: `/aero-namespace-001//user-001`.
+// Host identifies the cluster for the reader & is not part of [aerospike.Key].
+//
+// [aerospike.Key]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Key
+package recordurl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+// `key_type` URL query parameter values.
+const (
+	KeyTypeString = "string"
+	KeyTypeInt    = "int"
+	KeyTypeBytes  = "bytes"
+)
+
+// Path segment preceding hex-encoded digest of digest-only record URL.
+const digestSegment = "digest"
+
+// Parses record URL into [aerospike.Key].
+// Returns error, if URL scheme is not `aerospike`, path is not a record path or key does not match `key_type`.
+//
+// [aerospike.Key]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Key
+func Parse(recordURL string) (*aerospike.Key, error) {
+	connURL, err := url.Parse(recordURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if connURL.Scheme != "aerospike" {
+		return nil, aerourl.ErrInvalidScheme
+	}
+
+	segments, err := pathSegments(connURL.EscapedPath())
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, set := segments[0], segments[1]
+	if namespace == "" {
+		return nil, aerourl.ErrEmptyNamespace
+	}
+
+	if len(segments) == 4 {
+		if segments[2] != digestSegment {
+			return nil, ErrInvalidPath
+		}
+
+		return parseDigestKey(namespace, set, segments[3])
+	}
+
+	userKey, err := parseUserKey(segments[2], connURL.Query().Get("key_type"))
+	if err != nil {
+		return nil, err
+	}
+
+	key, aeroErr := aerospike.NewKey(namespace, set, userKey)
+	if aeroErr != nil {
+		return nil, aeroErr
+	}
+
+	return key, nil
+}
+
+// Formats [aerospike.Key] as record URL of host (`hostname[:port]`).
+// Keys with user key are formatted with `key_type`, digest-only keys are formatted in digest form.
+// Empty string key is formatted with explicit `key_type=string`, so it is told apart from a missing key.
+// Returns error, if key is nil or user key is not a string, integer or bytes.
+//
+// [aerospike.Key]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Key
+func Format(host string, key *aerospike.Key) (string, error) {
+	if key == nil {
+		return "", ErrNilKey
+	}
+
+	var builder strings.Builder
+	builder.WriteString("aerospike://" + host)
+	builder.WriteString("/" + url.PathEscape(key.Namespace()))
+	builder.WriteString("/" + url.PathEscape(key.SetName()))
+
+	switch value := key.Value().(type) {
+	case nil, aerospike.NullValue:
+		builder.WriteString("/" + digestSegment + "/" + hex.EncodeToString(key.Digest()))
+	case aerospike.StringValue:
+		builder.WriteString("/" + url.PathEscape(string(value)))
+		if value == "" {
+			builder.WriteString("?key_type=" + KeyTypeString)
+		}
+	case aerospike.IntegerValue:
+		builder.WriteString("/" + strconv.Itoa(int(value)) + "?key_type=" + KeyTypeInt)
+	case aerospike.LongValue:
+		builder.WriteString("/" + strconv.FormatInt(int64(value), 10) + "?key_type=" + KeyTypeInt)
+	case aerospike.BytesValue:
+		builder.WriteString("/" + hex.EncodeToString(value) + "?key_type=" + KeyTypeBytes)
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, value)
+	}
+
+	return builder.String(), nil
+}
+
+// Splits escaped URL path into unescaped `namespace, set, key` or `namespace, set, digest, <hex>` segments.
+func pathSegments(escapedPath string) ([]string, error) {
+	segments := strings.Split(strings.TrimPrefix(escapedPath, "/"), "/")
+	if len(segments) != 3 && len(segments) != 4 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPath, escapedPath)
+	}
+
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+
+		segments[i] = unescaped
+	}
+
+	return segments, nil
+}
+
+// Parses user key according to `key_type`. Empty `key_type` is treated as `string`,
+// except for empty key, which requires explicit `key_type=string`.
+func parseUserKey(keyStr string, keyType string) (interface{}, error) {
+	switch keyType {
+	case "":
+		if keyStr == "" {
+			return nil, fmt.Errorf("%w: empty key", ErrInvalidKey)
+		}
+
+		return keyStr, nil
+	case KeyTypeString:
+		return keyStr, nil
+	case KeyTypeInt:
+		userKey, err := strconv.ParseInt(keyStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s is not int", ErrInvalidKey, keyStr)
+		}
+
+		return userKey, nil
+	case KeyTypeBytes:
+		userKey, err := hex.DecodeString(keyStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s is not hex-encoded bytes", ErrInvalidKey, keyStr)
+		}
+
+		return userKey, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInvalidKeyType, keyType)
+}
+
+// Creates digest-only [aerospike.Key] from hex-encoded digest.
+//
+// [aerospike.Key]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Key
+func parseDigestKey(namespace string, set string, digestStr string) (*aerospike.Key, error) {
+	digest, err := hex.DecodeString(digestStr)
+	if err != nil || len(digest) != 20 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDigest, digestStr)
+	}
+
+	key, aeroErr := aerospike.NewKeyWithDigest(namespace, set, nil, digest)
+	if aeroErr != nil {
+		return nil, aeroErr
+	}
+
+	return key, nil
+}
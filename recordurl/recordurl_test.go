@@ -0,0 +1,119 @@
+package recordurl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]interface{}{
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/user-001":                 "user-001",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/user%2F001":               "user/001",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/42?key_type=int":          int64(42),
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/0a0b0c?key_type=bytes":    []byte{0x0a, 0x0b, 0x0c},
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/user-001?key_type=string": "user-001",
+	}
+
+	for recordURL, userKey := range tests {
+		key, err := Parse(recordURL)
+		if err != nil {
+			t.Fatalf("got: %v, want: error = nil (%s)", err, recordURL)
+		}
+
+		want, _ := aerospike.NewKey("aero-namespace-001", "users", userKey)
+		if !bytes.Equal(key.Digest(), want.Digest()) || key.Namespace() != "aero-namespace-001" || key.SetName() != "users" {
+			t.Errorf("got: %v, want: %v", key, want)
+		}
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	want, _ := aerospike.NewKey("aero-namespace-001", "", "user-001")
+
+	key, err := Parse("aerospike://127.0.0.1:3000/aero-namespace-001//digest/" + hexDigest(want))
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if key.SetName() != "" || !bytes.Equal(key.Digest(), want.Digest()) {
+		t.Errorf("got: %v, want: digest-only key of %v", key, want)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]error{
+		"https://127.0.0.1:3000/aero-namespace-001/users/user-001":               aerourl.ErrInvalidScheme,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users":                    ErrInvalidPath,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/a/b/c":              ErrInvalidPath,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/user/001":           ErrInvalidPath,
+		"aerospike://127.0.0.1:3000//users/user-001":                             aerourl.ErrEmptyNamespace,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/":                   ErrInvalidKey,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/abc?key_type=int":   ErrInvalidKey,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/xyz?key_type=bytes": ErrInvalidKey,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/abc?key_type=float": ErrInvalidKeyType,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/digest/0a0b":        ErrInvalidDigest,
+	}
+
+	for recordURL, want := range tests {
+		if _, err := Parse(recordURL); !errors.Is(err, want) {
+			t.Errorf("got: %v, want: error is %v (%s)", err, want, recordURL)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	digestKey, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+	digestOnly, _ := aerospike.NewKeyWithDigest("aero-namespace-001", "users", nil, digestKey.Digest())
+
+	tests := map[string]interface{}{
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/user%2F001":            "user/001",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/42?key_type=int":       42,
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/-7?key_type=int":       int64(-7),
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/0a0b0c?key_type=bytes": []byte{0x0a, 0x0b, 0x0c},
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/?key_type=string":      "",
+		"aerospike://127.0.0.1:3000/aero-namespace-001/users/?key_type=bytes":       []byte{},
+	}
+
+	for want, userKey := range tests {
+		key, _ := aerospike.NewKey("aero-namespace-001", "users", userKey)
+
+		recordURL, err := Format("127.0.0.1:3000", key)
+		if err != nil || recordURL != want {
+			t.Fatalf("got: %v (%v), want: %v", recordURL, err, want)
+		}
+
+		parsed, err := Parse(recordURL)
+		if err != nil || !bytes.Equal(parsed.Digest(), key.Digest()) {
+			t.Errorf("got: %v (%v), want: %v", parsed, err, key)
+		}
+	}
+
+	recordURL, err := Format("127.0.0.1:3000", digestOnly)
+	if want := "aerospike://127.0.0.1:3000/aero-namespace-001/users/digest/" + hexDigest(digestKey); err != nil || recordURL != want {
+		t.Errorf("got: %v (%v), want: %v", recordURL, err, want)
+	}
+}
+
+func TestFormatUnsupportedKey(t *testing.T) {
+	key, _ := aerospike.NewKey("aero-namespace-001", "users", "user-001")
+	key.SetValue(aerospike.NewFloatValue(4.2))
+
+	if _, err := Format("127.0.0.1:3000", key); !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("got: %v, want: error is recordurl.ErrUnsupportedKey", err)
+	}
+}
+
+func TestFormatNilKey(t *testing.T) {
+	if _, err := Format("127.0.0.1:3000", nil); !errors.Is(err, ErrNilKey) {
+		t.Errorf("got: %v, want: error is recordurl.ErrNilKey", err)
+	}
+}
+
+func hexDigest(key *aerospike.Key) string {
+	return hex.EncodeToString(key.Digest())
+}
@@ -0,0 +1,182 @@
+// Root package
+package aerospikeurl
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Serves as a named collection of Aerospike connections for services talking to several clusters.
+// Connection strings are parsed once at [Registry.Register], while [aerospike.Client] is built lazily
+// on first [Registry.Client] call & cached per name. Safe for concurrent use.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+type Registry struct {
+	mu          sync.RWMutex
+	connections map[string]*registryConnection
+}
+
+// Holds parsed factory & lazily built client of a single registered connection.
+// Client is built & closed under mu, but can be loaded without it (See: [Registry.BuiltClient]).
+type registryConnection struct {
+	mu            sync.Mutex
+	clientFactory *aerofactory.AerospikeClientFactory
+	client        atomic.Pointer[aerospike.Client]
+}
+
+// Builds client from factory. Replaced in tests to avoid connecting to Aerospike DB.
+var buildRegistryClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+	client, err := clientFactory.BuildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Process-wide registry used by package-level [Register], [Factory], [Client] & [CloseAll].
+var defaultRegistry = NewRegistry()
+
+// Initializes empty [Registry].
+func NewRegistry() *Registry {
+	return &Registry{connections: map[string]*registryConnection{}}
+}
+
+// Parses connection string (See: [Parse]) & registers it under name.
+// Returns error, if connection string is invalid or name is already registered.
+func (registry *Registry) Register(name string, connStr string) error {
+	clientFactory, err := Parse(connStr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.connections[name]; ok {
+		return fmt.Errorf("%w: %s", ErrConnectionExists, name)
+	}
+
+	registry.connections[name] = &registryConnection{clientFactory: clientFactory}
+	return nil
+}
+
+// Returns [aerofactory.AerospikeClientFactory] registered under name.
+// Returns error, if name is not registered.
+func (registry *Registry) Factory(name string) (*aerofactory.AerospikeClientFactory, error) {
+	connection, err := registry.connection(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection.clientFactory, nil
+}
+
+// Returns [aerospike.Client] registered under name, building it on first call.
+// Failed builds are not cached, so the next call retries.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (registry *Registry) Client(name string) (*aerospike.Client, error) {
+	connection, err := registry.connection(name)
+	if err != nil {
+		return nil, err
+	}
+
+	connection.mu.Lock()
+	defer connection.mu.Unlock()
+
+	if client := connection.client.Load(); client != nil {
+		return client, nil
+	}
+
+	client, err := buildRegistryClient(connection.clientFactory)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	connection.client.Store(client)
+	return client, nil
+}
+
+// Returns [aerospike.Client] registered under name, if it was already built, or nil otherwise.
+// Never builds client & never waits for a build in progress, so it is safe to call from health checks.
+// Returns error, if name is not registered.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (registry *Registry) BuiltClient(name string) (*aerospike.Client, error) {
+	connection, err := registry.connection(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection.client.Load(), nil
+}
+
+// Returns registered connection names in alphabetical order.
+func (registry *Registry) Names() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	names := make([]string, 0, len(registry.connections))
+	for name := range registry.connections {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Closes every client built by the registry. Connections stay registered,
+// so the next [Registry.Client] call builds a new client.
+func (registry *Registry) CloseAll() {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	for _, connection := range registry.connections {
+		connection.mu.Lock()
+
+		if client := connection.client.Swap(nil); client != nil {
+			closeClient(connection.clientFactory, client)
+		}
+
+		connection.mu.Unlock()
+	}
+}
+
+// Returns registered connection or error, if name is not registered.
+func (registry *Registry) connection(name string) (*registryConnection, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	connection, ok := registry.connections[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrConnectionNotFound, name)
+	}
+
+	return connection, nil
+}
+
+// Registers connection string under name in process-wide registry (See: [Registry.Register]).
+func Register(name string, connStr string) error {
+	return defaultRegistry.Register(name, connStr)
+}
+
+// Returns factory registered under name in process-wide registry (See: [Registry.Factory]).
+func Factory(name string) (*aerofactory.AerospikeClientFactory, error) {
+	return defaultRegistry.Factory(name)
+}
+
+// Returns client registered under name in process-wide registry (See: [Registry.Client]).
+func Client(name string) (*aerospike.Client, error) {
+	return defaultRegistry.Client(name)
+}
+
+// Closes every client of process-wide registry (See: [Registry.CloseAll]).
+func CloseAll() {
+	defaultRegistry.CloseAll()
+}
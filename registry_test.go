@@ -0,0 +1,164 @@
+package aerospikeurl
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewRegistry()
+
+	if err := registry.Register("cache", "aerospike://127.0.0.1:3000/cache"); err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	clientFactory, err := registry.Factory("cache")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if clientFactory.GetNamespace() != "cache" {
+		t.Errorf("got: %v, want: cache", clientFactory.GetNamespace())
+	}
+}
+
+func TestRegistryRegisterDuplicate(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("cache", "aerospike://127.0.0.1:3000/cache")
+
+	err := registry.Register("cache", "aerospike://127.0.0.1:3000/cache")
+	if !errors.Is(err, ErrConnectionExists) {
+		t.Errorf("got: %v, want: error is aerospikeurl.ErrConnectionExists", err)
+	}
+}
+
+func TestRegistryRegisterInvalid(t *testing.T) {
+	registry := NewRegistry()
+
+	err := registry.Register("cache", "https://127.0.0.1:3000/cache")
+	if !errors.Is(err, aerourl.ErrInvalidScheme) {
+		t.Errorf("got: %v, want: error is aerourl.ErrInvalidScheme", err)
+	}
+}
+
+func TestRegistryNotFound(t *testing.T) {
+	registry := NewRegistry()
+
+	if _, err := registry.Factory("counters"); !errors.Is(err, ErrConnectionNotFound) {
+		t.Errorf("got: %v, want: error is aerospikeurl.ErrConnectionNotFound", err)
+	}
+
+	if _, err := registry.Client("counters"); !errors.Is(err, ErrConnectionNotFound) {
+		t.Errorf("got: %v, want: error is aerospikeurl.ErrConnectionNotFound", err)
+	}
+}
+
+func TestRegistryClientCached(t *testing.T) {
+	var builds atomic.Int32
+
+	build := buildRegistryClient
+	buildRegistryClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+		builds.Add(1)
+		return &aerospike.Client{}, nil
+	}
+	t.Cleanup(func() { buildRegistryClient = build })
+
+	registry := NewRegistry()
+	registry.Register("profiles", "aerospike://127.0.0.1:3000/profiles")
+
+	var wg sync.WaitGroup
+	clients := make([]*aerospike.Client, 10)
+
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = registry.Client("profiles")
+		}(i)
+	}
+	wg.Wait()
+
+	if builds.Load() != 1 {
+		t.Errorf("got: %v builds, want: 1", builds.Load())
+	}
+
+	for _, client := range clients {
+		if client != clients[0] {
+			t.Fatalf("got: %p, want: %p", client, clients[0])
+		}
+	}
+}
+
+func TestRegistryClientBuildErrorNotCached(t *testing.T) {
+	var builds atomic.Int32
+
+	build := buildRegistryClient
+	buildRegistryClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+		if builds.Add(1) == 1 {
+			return nil, errors.New("connection refused")
+		}
+
+		return &aerospike.Client{}, nil
+	}
+	t.Cleanup(func() { buildRegistryClient = build })
+
+	registry := NewRegistry()
+	registry.Register("counters", "aerospike://127.0.0.1:3000/counters")
+
+	if _, err := registry.Client("counters"); err == nil {
+		t.Fatal("got: error = nil, want: error != nil")
+	}
+
+	client, err := registry.Client("counters")
+	if err != nil || client == nil {
+		t.Fatalf("got: %v, want: client built on retry", err)
+	}
+
+	if builds.Load() != 2 {
+		t.Errorf("got: %v builds, want: 2", builds.Load())
+	}
+}
+
+func TestRegistryNames(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("profiles", "aerospike://127.0.0.1:3000/profiles")
+	registry.Register("cache", "aerospike://127.0.0.1:3000/cache")
+
+	names := registry.Names()
+	if len(names) != 2 || names[0] != "cache" || names[1] != "profiles" {
+		t.Errorf("got: %v, want: [cache profiles]", names)
+	}
+}
+
+func TestRegistryBuiltClient(t *testing.T) {
+	var builds atomic.Int32
+
+	build := buildRegistryClient
+	buildRegistryClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+		builds.Add(1)
+		return &aerospike.Client{}, nil
+	}
+	t.Cleanup(func() { buildRegistryClient = build })
+
+	registry := NewRegistry()
+	registry.Register("profiles", "aerospike://127.0.0.1:3000/profiles")
+
+	if client, err := registry.BuiltClient("profiles"); client != nil || err != nil || builds.Load() != 0 {
+		t.Fatalf("got: %v, %v, %v builds, want: nil client without building", client, err, builds.Load())
+	}
+
+	built, _ := registry.Client("profiles")
+	if client, _ := registry.BuiltClient("profiles"); client != built {
+		t.Errorf("got: %p, want: %p", client, built)
+	}
+
+	if _, err := registry.BuiltClient("counters"); !errors.Is(err, ErrConnectionNotFound) {
+		t.Errorf("got: %v, want: error is aerospikeurl.ErrConnectionNotFound", err)
+	}
+}
@@ -0,0 +1,205 @@
+// Root package
+package aerospikeurl
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Default time a replaced client is kept open, so in-flight operations on it can finish.
+const defaultReloadGracePeriod = 5 * time.Second
+
+// Serves as a stable accessor to [aerospike.Client] built from connection string stored in a file.
+// When the file changes, connection string is re-parsed & if its canonical form (See: [Canonical]) changed,
+// a new client is built & swapped atomically. Invalid updates are rejected & reported,
+// while the previous client stays live.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+type Reloader struct {
+	path        string
+	gracePeriod time.Duration
+
+	current atomic.Pointer[reloaderState]
+
+	mu          sync.Mutex
+	lastContent []byte
+	lastErr     error
+	closed      bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	// Tracks replaced clients waiting for grace period to be closed
+	closing sync.WaitGroup
+}
+
+// Holds client & factory built from a single connection string.
+type reloaderState struct {
+	clientFactory *aerofactory.AerospikeClientFactory
+	client        *aerospike.Client
+	key           string
+}
+
+// Reads connection string from file, parses it & builds initial client.
+// Returns error, if file cannot be read, connection string is invalid or client cannot be built.
+func NewReloader(path string) (*Reloader, error) {
+	reloader := &Reloader{
+		path:        path,
+		gracePeriod: defaultReloadGracePeriod,
+		stop:        make(chan struct{}),
+	}
+
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// Returns current [aerospike.Client]. Callers should not keep it for long,
+// as it is closed after grace period once replaced.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (reloader *Reloader) Client() *aerospike.Client {
+	return reloader.current.Load().client
+}
+
+// Returns [aerofactory.AerospikeClientFactory] current client was built with.
+func (reloader *Reloader) Factory() *aerofactory.AerospikeClientFactory {
+	return reloader.current.Load().clientFactory
+}
+
+// Returns error of the last rejected update or nil, if the last update was applied.
+func (reloader *Reloader) Err() error {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	return reloader.lastErr
+}
+
+// Re-reads file & swaps client, if connection string changed.
+// Returns error, if update was rejected. Previous client is kept live in that case.
+// If client cannot be built, [Reloader.Watch] keeps retrying until it succeeds or file changes.
+// Returns [ErrReloaderClosed] after [Reloader.Close].
+func (reloader *Reloader) Reload() error {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	if reloader.closed {
+		return ErrReloaderClosed
+	}
+
+	content, err := os.ReadFile(reloader.path)
+	if err != nil {
+		return reloader.reject(err)
+	}
+
+	content = bytes.TrimSpace(content)
+
+	clientFactory, err := Parse(string(content))
+	if err != nil {
+		// Invalid content is not retried until file changes
+		reloader.lastContent = content
+		return reloader.reject(err)
+	}
+
+	key := Canonical(clientFactory)
+
+	previous := reloader.current.Load()
+	if previous != nil && previous.key == key {
+		reloader.lastContent = content
+		reloader.lastErr = nil
+		return nil
+	}
+
+	client, err := buildClient(clientFactory)
+	if err != nil {
+		return reloader.reject(err)
+	}
+
+	reloader.current.Store(&reloaderState{clientFactory, client, key})
+	reloader.lastContent = content
+	reloader.lastErr = nil
+
+	if previous != nil {
+		reloader.closing.Add(1)
+		time.AfterFunc(reloader.gracePeriod, func() {
+			defer reloader.closing.Done()
+			closeClient(previous.clientFactory, previous.client)
+		})
+	}
+
+	return nil
+}
+
+// Starts polling file every interval in background. On change, [Reloader.Reload] is called
+// & rejected updates are passed to onError (if it is not nil).
+// Polling stops at [Reloader.Close].
+func (reloader *Reloader) Watch(interval time.Duration, onError func(error)) {
+	reloader.wg.Add(1)
+
+	go func() {
+		defer reloader.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-reloader.stop:
+				return
+			case <-ticker.C:
+				if !reloader.changed() {
+					continue
+				}
+
+				if err := reloader.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// Stops watching file & closes current client. Replaced clients are closed once their grace period ends.
+func (reloader *Reloader) Close() {
+	reloader.stopOnce.Do(func() {
+		close(reloader.stop)
+		reloader.wg.Wait()
+
+		reloader.mu.Lock()
+		reloader.closed = true
+		current := reloader.current.Load()
+		reloader.mu.Unlock()
+
+		closeClient(current.clientFactory, current.client)
+	})
+}
+
+// Reports whether file content differs from the last applied one.
+// Unreadable file & content, which client failed to be built from, are reported as changed,
+// so [Reloader.Reload] retries them.
+func (reloader *Reloader) changed() bool {
+	content, err := os.ReadFile(reloader.path)
+	if err != nil {
+		return true
+	}
+
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	return !bytes.Equal(bytes.TrimSpace(content), reloader.lastContent)
+}
+
+// Records rejected update error & returns it.
+func (reloader *Reloader) reject(err error) error {
+	reloader.lastErr = err
+	return err
+}
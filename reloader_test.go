@@ -0,0 +1,181 @@
+package aerospikeurl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+	"github.com/tiptophelmet/aerospike-url/aerourl"
+)
+
+// Writes connection string into file.
+func writeConnStr(t *testing.T, path string, connStr string) {
+	if err := os.WriteFile(path, []byte(connStr+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloaderInitial(t *testing.T) {
+	builds, _ := stubClients(t)
+
+	path := filepath.Join(t.TempDir(), "aerospike-url")
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-001")
+
+	reloader, err := NewReloader(path)
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+	defer reloader.Close()
+
+	if reloader.Client() == nil || builds.Load() != 1 {
+		t.Errorf("got: %v builds, want: initial client", builds.Load())
+	}
+
+	if reloader.Factory().GetNamespace() != "aero-namespace-001" {
+		t.Errorf("got: %v, want: aero-namespace-001", reloader.Factory().GetNamespace())
+	}
+}
+
+func TestReloaderInvalidInitial(t *testing.T) {
+	stubClients(t)
+
+	path := filepath.Join(t.TempDir(), "aerospike-url")
+	writeConnStr(t, path, "https://127.0.0.1:3000/aero-namespace-001")
+
+	if _, err := NewReloader(path); !errors.Is(err, aerourl.ErrInvalidScheme) {
+		t.Errorf("got: %v, want: error is aerourl.ErrInvalidScheme", err)
+	}
+}
+
+func TestReloaderReload(t *testing.T) {
+	builds, closes := stubClients(t)
+
+	path := filepath.Join(t.TempDir(), "aerospike-url")
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-001?timeout=10s&idle_timeout=3s")
+
+	reloader, _ := NewReloader(path)
+	reloader.gracePeriod = 0
+	initial := reloader.Client()
+
+	// Equivalent connection string keeps the client
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-001?idle_timeout=3s&timeout=10s")
+	if err := reloader.Reload(); err != nil || reloader.Client() != initial || builds.Load() != 1 {
+		t.Fatalf("got: %v, %v builds, want: client kept", err, builds.Load())
+	}
+
+	// Invalid update is rejected, previous client stays live
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000")
+	if err := reloader.Reload(); !errors.Is(err, aerourl.ErrEmptyNamespace) || reloader.Client() != initial {
+		t.Fatalf("got: %v, want: error is aerourl.ErrEmptyNamespace & client kept", err)
+	}
+
+	if !errors.Is(reloader.Err(), aerourl.ErrEmptyNamespace) {
+		t.Errorf("got: %v, want: Err() is aerourl.ErrEmptyNamespace", reloader.Err())
+	}
+
+	// Changed connection string swaps the client
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-001?timeout=20s")
+	if err := reloader.Reload(); err != nil || reloader.Client() == initial || builds.Load() != 2 {
+		t.Fatalf("got: %v, %v builds, want: client swapped", err, builds.Load())
+	}
+
+	if reloader.Err() != nil {
+		t.Errorf("got: %v, want: Err() = nil", reloader.Err())
+	}
+
+	reloader.closing.Wait()
+	reloader.Close()
+
+	if closes.Load() != 2 {
+		t.Errorf("got: %v closes, want: 2", closes.Load())
+	}
+
+	if err := reloader.Reload(); !errors.Is(err, ErrReloaderClosed) || builds.Load() != 2 {
+		t.Errorf("got: %v, %v builds, want: error is aerospikeurl.ErrReloaderClosed & no build", err, builds.Load())
+	}
+}
+
+func TestReloaderWatchRetriesBuildError(t *testing.T) {
+	builds, _ := stubClients(t)
+
+	path := filepath.Join(t.TempDir(), "aerospike-url")
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-001")
+
+	reloader, _ := NewReloader(path)
+	defer reloader.Close()
+
+	build := buildClient
+	buildClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+		// Cluster is unreachable for the first attempt only
+		if builds.Add(1) == 2 {
+			return nil, errors.New("connection refused")
+		}
+
+		return &aerospike.Client{}, nil
+	}
+	t.Cleanup(func() { buildClient = build })
+
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-002")
+	if err := reloader.Reload(); err == nil {
+		t.Fatal("got: error = nil, want: error != nil")
+	}
+
+	reloader.Watch(time.Millisecond, nil)
+	waitFor(t, func() bool { return reloader.Factory().GetNamespace() == "aero-namespace-002" })
+
+	if reloader.Err() != nil {
+		t.Errorf("got: %v, want: Err() = nil after retry", reloader.Err())
+	}
+}
+
+func TestReloaderWatch(t *testing.T) {
+	stubClients(t)
+
+	path := filepath.Join(t.TempDir(), "aerospike-url")
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-001")
+
+	reloader, _ := NewReloader(path)
+	defer reloader.Close()
+
+	errs := make(chan error, 1)
+	reloader.Watch(time.Millisecond, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+
+	writeConnStr(t, path, "aerospike://127.0.0.1:3000/aero-namespace-002")
+	waitFor(t, func() bool { return reloader.Factory().GetNamespace() == "aero-namespace-002" })
+
+	writeConnStr(t, path, "https://127.0.0.1:3000/aero-namespace-003")
+	select {
+	case err := <-errs:
+		if !errors.Is(err, aerourl.ErrInvalidScheme) {
+			t.Errorf("got: %v, want: error is aerourl.ErrInvalidScheme", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("got: no error reported, want: rejected update reported")
+	}
+
+	if reloader.Factory().GetNamespace() != "aero-namespace-002" {
+		t.Errorf("got: %v, want: aero-namespace-002 kept", reloader.Factory().GetNamespace())
+	}
+}
+
+// Waits until condition is met or fails test after a second.
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("got: condition not met, want: condition met within 1s")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
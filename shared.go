@@ -0,0 +1,160 @@
+// Root package
+package aerospikeurl
+
+import (
+	"sync"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Serves as a cache of reference-counted clients shared between holders of equivalent connection strings.
+// Connection strings are keyed by [Canonical], so parameter order, explicit defaults & seed host order
+// do not open extra connection pools. Underlying [aerospike.Client] is closed when the last holder releases it.
+// Safe for concurrent use.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+type ClientCache struct {
+	mu      sync.Mutex
+	entries map[string]*sharedEntry
+}
+
+// Holds a shared client, factory it was built from & number of its holders.
+type sharedEntry struct {
+	mu            sync.Mutex
+	clientFactory *aerofactory.AerospikeClientFactory
+	client        *aerospike.Client
+	refs          int
+}
+
+// Builds shared client from factory. Replaced in tests to avoid connecting to Aerospike DB.
+var buildClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+	client, err := clientFactory.BuildClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Closes client built from factory (See: [aerofactory.AerospikeClientFactory.CloseClient]).
+// Replaced in tests along with [buildClient].
+var closeClient = func(clientFactory *aerofactory.AerospikeClientFactory, client *aerospike.Client) {
+	clientFactory.CloseClient(client)
+}
+
+// Process-wide cache used by [AcquireShared].
+var defaultClientCache = NewClientCache()
+
+// Initializes empty [ClientCache].
+func NewClientCache() *ClientCache {
+	return &ClientCache{entries: map[string]*sharedEntry{}}
+}
+
+// Parses connection string (See: [Parse]) & returns shared client for it (See: [ClientCache.AcquireFactory]).
+func (cache *ClientCache) Acquire(connStr string) (*SharedClient, error) {
+	clientFactory, err := Parse(connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.AcquireFactory(clientFactory)
+}
+
+// Returns shared client for [aerofactory.AerospikeClientFactory].
+// Client is built on first acquisition of equivalent configuration & reused by the following ones.
+// Every acquired [SharedClient] must be released with [SharedClient.Release].
+func (cache *ClientCache) AcquireFactory(clientFactory *aerofactory.AerospikeClientFactory) (*SharedClient, error) {
+	key := Canonical(clientFactory)
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &sharedEntry{}
+		cache.entries[key] = entry
+	}
+	entry.refs++
+	cache.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.client == nil {
+		client, err := buildClient(clientFactory)
+		if err != nil {
+			cache.release(key, entry)
+			return nil, err
+		}
+
+		entry.clientFactory, entry.client = clientFactory, client
+	}
+
+	return &SharedClient{cache: cache, key: key, entry: entry, client: entry.client}, nil
+}
+
+// Returns number of distinct clients currently held.
+func (cache *ClientCache) Len() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return len(cache.entries)
+}
+
+// Drops a reference to entry & closes its client, if it was the last one.
+// Client is closed after cache lock is released, so closing does not block other acquisitions.
+func (cache *ClientCache) release(key string, entry *sharedEntry) {
+	cache.mu.Lock()
+
+	entry.refs--
+	if entry.refs > 0 {
+		cache.mu.Unlock()
+		return
+	}
+
+	if cache.entries[key] == entry {
+		delete(cache.entries, key)
+	}
+
+	clientFactory, client := entry.clientFactory, entry.client
+	entry.client = nil
+
+	cache.mu.Unlock()
+
+	if client != nil {
+		closeClient(clientFactory, client)
+	}
+}
+
+// Serves as a holder's reference to a client shared through [ClientCache].
+type SharedClient struct {
+	cache  *ClientCache
+	key    string
+	entry  *sharedEntry
+	client *aerospike.Client
+	once   sync.Once
+}
+
+// Returns shared [aerospike.Client]. It must not be closed directly, use [SharedClient.Release] instead.
+//
+// [aerospike.Client]: https://pkg.go.dev/github.com/aerospike/aerospike-client-go/v6#Client
+func (shared *SharedClient) Client() *aerospike.Client {
+	return shared.client
+}
+
+// Returns canonical connection string the client is shared by (See: [Canonical]).
+func (shared *SharedClient) Key() string {
+	return shared.key
+}
+
+// Releases holder's reference. Underlying client is closed, when the last holder releases it.
+// Calling Release more than once has no effect.
+func (shared *SharedClient) Release() {
+	shared.once.Do(func() {
+		shared.cache.release(shared.key, shared.entry)
+	})
+}
+
+// Parses connection string & returns client shared process-wide (See: [ClientCache.Acquire]).
+func AcquireShared(connStr string) (*SharedClient, error) {
+	return defaultClientCache.Acquire(connStr)
+}
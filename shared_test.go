@@ -0,0 +1,101 @@
+package aerospikeurl
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/aerospike/aerospike-client-go/v6"
+	"github.com/tiptophelmet/aerospike-url/aerofactory"
+)
+
+// Replaces client building & closing with counters for the duration of the test.
+func stubClients(t *testing.T) (builds *atomic.Int32, closes *atomic.Int32) {
+	builds, closes = new(atomic.Int32), new(atomic.Int32)
+
+	build, closeFn := buildClient, closeClient
+	buildClient = func(clientFactory *aerofactory.AerospikeClientFactory) (*aerospike.Client, error) {
+		builds.Add(1)
+		return &aerospike.Client{}, nil
+	}
+	closeClient = func(clientFactory *aerofactory.AerospikeClientFactory, client *aerospike.Client) {
+		closes.Add(1)
+	}
+
+	t.Cleanup(func() { buildClient, closeClient = build, closeFn })
+	return builds, closes
+}
+
+func TestClientCacheSharesEquivalentURLs(t *testing.T) {
+	builds, closes := stubClients(t)
+	cache := NewClientCache()
+
+	first, err := cache.Acquire("aerospike://127.0.0.1:3000/aero-namespace-001?timeout=10s&idle_timeout=3s")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	second, err := cache.Acquire("aerospike://127.0.0.1:3000/aero-namespace-001?idle_timeout=3s&timeout=10s")
+	if err != nil {
+		t.Fatalf("got: %v, want: error = nil", err)
+	}
+
+	if first.Client() != second.Client() || builds.Load() != 1 {
+		t.Fatalf("got: %v builds, want: 1 shared client", builds.Load())
+	}
+
+	first.Release()
+	first.Release()
+	if closes.Load() != 0 {
+		t.Errorf("got: %v closes, want: 0 while client is held", closes.Load())
+	}
+
+	second.Release()
+	if closes.Load() != 1 || cache.Len() != 0 {
+		t.Errorf("got: %v closes, %v entries, want: client closed after last release", closes.Load(), cache.Len())
+	}
+}
+
+func TestClientCacheDifferentURLs(t *testing.T) {
+	builds, _ := stubClients(t)
+	cache := NewClientCache()
+
+	first, _ := cache.Acquire("aerospike://127.0.0.1:3000/aero-namespace-001")
+	second, _ := cache.Acquire("aerospike://127.0.0.1:3000/aero-namespace-002")
+	defer first.Release()
+	defer second.Release()
+
+	if builds.Load() != 2 || cache.Len() != 2 {
+		t.Errorf("got: %v builds, %v entries, want: 2 clients", builds.Load(), cache.Len())
+	}
+}
+
+func TestClientCacheBuildError(t *testing.T) {
+	cache := NewClientCache()
+
+	if _, err := cache.Acquire("aerospike://127.0.0.1:1/aero-namespace-001"); err == nil {
+		t.Fatal("got: error = nil, want: error != nil")
+	}
+
+	if cache.Len() != 0 {
+		t.Errorf("got: %v entries, want: 0", cache.Len())
+	}
+}
+
+func TestClientCacheClosesOutsideLock(t *testing.T) {
+	stubClients(t)
+	cache := NewClientCache()
+
+	closeFn := closeClient
+	closeClient = func(clientFactory *aerofactory.AerospikeClientFactory, client *aerospike.Client) {
+		// Would deadlock, if client was closed while holding cache lock
+		cache.Len()
+	}
+	t.Cleanup(func() { closeClient = closeFn })
+
+	shared, _ := cache.Acquire("aerospike://127.0.0.1:3000/aero-namespace-001")
+	shared.Release()
+
+	if cache.Len() != 0 {
+		t.Errorf("got: %v entries, want: 0", cache.Len())
+	}
+}